@@ -0,0 +1,280 @@
+package env
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+)
+
+// FieldError describes a struct field Bind could not fill.
+type FieldError struct {
+	// Field is the path of the field, e.g. "Config.DB.Host".
+	Field string
+	// Key is the environment variable the field is bound to.
+	Key string
+	// Err is the reason the field could not be filled, usually an *Error.
+	Err error
+}
+
+func (e *FieldError) Error() string {
+	return e.Err.Error() + " (field " + e.Field + ")"
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// BindError reports every field Bind could not fill.
+type BindError struct {
+	Errors []*FieldError
+}
+
+func (e *BindError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+
+	return strings.Join(msgs, "\n")
+}
+
+func (e *BindError) Unwrap() []error {
+	return unwrapAll(e.Errors)
+}
+
+func (e *BindError) Is(target error) bool {
+	return isAny(e.Errors, target)
+}
+
+func (e *BindError) As(target any) bool {
+	return asAny(e.Errors, target)
+}
+
+// Bind is like the package-level Bind but reads from the source of 'e'.
+func (e *Env) Bind(v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("env: Bind requires a non-nil pointer to a struct")
+	}
+
+	var errs []*FieldError
+	e.bindStruct(rv.Elem(), rv.Elem().Type().Name(), "", nil, &errs)
+
+	if len(errs) > 0 {
+		return &BindError{Errors: errs}
+	}
+
+	return nil
+}
+
+// MustBind is like the package-level MustBind but reads from the source of 'e'.
+func (e *Env) MustBind(v any) {
+	if err := e.Bind(v); err != nil {
+		panic(err)
+	}
+}
+
+// Bind fills the exported fields of the struct pointed to by 'v' from the environment.
+//
+// A field is bound to the environment variable named by its 'env' tag and
+// supports the following tags:
+//
+//	default:"value"      value used when the variable is not set
+//	required:"true"      the variable must be set, as in MustGet
+//	in:"a,b"             the value must be one of the list, as in GetIn
+//	except:"a,b"         the value must not be one of the list, as in GetExcept
+//	ignorecase:"true"    'in', 'except', 'glob' and 'exceptglob' are not case sensitive
+//	regex:"^a"           the value must match one of the expressions, as in GetInRegex
+//	exceptregex:"^a"     the value must not match the expressions, as in GetExceptRegex
+//	glob:"eu-*,us-*"     the value must match one of the glob patterns, as in GetInGlob
+//	exceptglob:"*-test"  the value must not match the glob patterns, as in GetExceptGlob
+//	nonempty:"true"      the value must not be empty, as in MustGetNonEmpty
+//	desc:"text"          description recorded in the registry of the Env
+//	secret:"true"        the value is sensitive and never displayed
+//
+// 'regex' and 'exceptregex' hold a single expression each since commas are
+// common in regular expressions.
+//
+// Nested and embedded structs, and pointers to them, are bound recursively.
+// A struct type nested in itself is reported as an error wrapping ErrCycle.
+// The 'env' tag of a struct field, if any, is used as a prefix for the keys
+// of its fields. Fields without an 'env' tag are left untouched, and so are
+// fields whose variable is not set and has no default.
+//
+// Bind tries every field and returns a *BindError listing all the fields it
+// could not fill.
+func Bind(v any) error {
+	return std.Bind(v)
+}
+
+// MustBind is like Bind but raises a panic with the returned error if any field could not be filled.
+func MustBind(v any) {
+	std.MustBind(v)
+}
+
+// bindStruct binds the fields of 'v'. 'parents' holds the struct types being bound
+// above 'v', so that a type nested in itself is reported instead of bound forever.
+func (e *Env) bindStruct(v reflect.Value, path, prefix string, parents []reflect.Type, errs *[]*FieldError) {
+	t := v.Type()
+	parents = append(parents[:len(parents):len(parents)], t)
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() && !f.Anonymous {
+			continue
+		}
+
+		key, hasKey := f.Tag.Lookup("env")
+		if key == "-" {
+			continue
+		}
+
+		fv := v.Field(i)
+		name := path + "." + f.Name
+
+		ft := f.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+
+		if ft.Kind() == reflect.Struct {
+			if chain, ok := cycle(parents, ft); ok {
+				k := e.key(prefix + key)
+				*errs = append(*errs, &FieldError{Field: name, Key: k, Err: &Error{Key: k, Constraint: chain, Err: ErrCycle}})
+				continue
+			}
+
+			if fv.Kind() == reflect.Pointer {
+				if fv.IsNil() {
+					if !fv.CanSet() {
+						continue
+					}
+
+					fv.Set(reflect.New(ft))
+				}
+
+				fv = fv.Elem()
+			}
+
+			e.bindStruct(fv, name, prefix+key, parents, errs)
+			continue
+		}
+
+		if !hasKey || !fv.CanSet() {
+			continue
+		}
+
+		if err := e.bindField(fv, prefix+key, f.Tag); err != nil {
+			*errs = append(*errs, &FieldError{Field: name, Key: e.key(prefix + key), Err: err})
+		}
+	}
+}
+
+// cycle returns the chain of types from the first occurrence of 't' in 'parents'
+// back to 't', e.g. "Node -> Node", and whether 't' occurs in 'parents'.
+func cycle(parents []reflect.Type, t reflect.Type) (string, bool) {
+	for i, p := range parents {
+		if p != t {
+			continue
+		}
+
+		names := make([]string, 0, len(parents)-i+1)
+		for _, p := range parents[i:] {
+			names = append(names, p.String())
+		}
+
+		return strings.Join(append(names, t.String()), " -> "), true
+	}
+
+	return "", false
+}
+
+func (e *Env) bindField(v reflect.Value, key string, tag reflect.StructTag) error {
+	target := v
+	if v.Kind() == reflect.Pointer {
+		target = reflect.New(v.Type().Elem()).Elem()
+	}
+
+	value, err := e.tagVar(key, tag).satisfy(func(key, s string) error {
+		if err := parseInto(s, target); err != nil {
+			return &Error{Key: key, Value: s, Constraint: "type " + target.Type().String(), Err: ErrInvalid}
+		}
+
+		return nil
+	}).Lookup()
+	if errors.Is(err, ErrNotSet) && tag.Get("required") != "true" {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	// The value is parsed again as it may be the default value, which is not checked.
+	if err := parseInto(value, target); err != nil {
+		return &Error{Key: key, Value: value, Constraint: "type " + target.Type().String(), Err: ErrInvalid}
+	}
+
+	if v.Kind() == reflect.Pointer {
+		v.Set(target.Addr())
+	}
+
+	return nil
+}
+
+// tagVar returns a Variable reading 'key' with the constraints declared in 'tag'.
+func (e *Env) tagVar(key string, tag reflect.StructTag) *Variable {
+	v := e.Var(key).Describe(tag.Get("desc"))
+
+	if def, ok := tag.Lookup("default"); ok && tag.Get("required") != "true" {
+		v.Default(def)
+	}
+
+	if tag.Get("secret") == "true" {
+		v.Secret()
+	}
+
+	if in, ok := tag.Lookup("in"); ok {
+		v.OneOf(splitTag(in)...)
+	}
+
+	if except, ok := tag.Lookup("except"); ok {
+		v.NotOneOf(splitTag(except)...)
+	}
+
+	if r, ok := tag.Lookup("regex"); ok {
+		v.Match(r)
+	}
+
+	if r, ok := tag.Lookup("exceptregex"); ok {
+		v.NotMatch(r)
+	}
+
+	if g, ok := tag.Lookup("glob"); ok {
+		v.Glob(splitTag(g)...)
+	}
+
+	if g, ok := tag.Lookup("exceptglob"); ok {
+		v.NotGlob(splitTag(g)...)
+	}
+
+	if tag.Get("nonempty") == "true" {
+		v.NonEmpty()
+	}
+
+	if tag.Get("ignorecase") == "true" {
+		v.CaseInsensitive()
+	}
+
+	return v
+}
+
+// splitTag splits the comma-separated list of a tag, trimming the spaces around each element.
+func splitTag(list string) []string {
+	elems := strings.Split(list, ",")
+	for i, el := range elems {
+		elems[i] = strings.TrimSpace(el)
+	}
+
+	return elems
+}
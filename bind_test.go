@@ -0,0 +1,198 @@
+package env_test
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gomodrepo/env"
+)
+
+type bindDB struct {
+	Host string `env:"HOST" default:"localhost"`
+	Port int    `env:"PORT" default:"5432"`
+}
+
+type bindEmbedded struct {
+	Debug bool `env:"TEST_DEBUG"`
+}
+
+type bindConfig struct {
+	bindEmbedded
+	Mode     string        `env:"TEST_MODE" default:"dev" in:"dev,prod" ignorecase:"true"`
+	Name     string        `env:"TEST_NAME" required:"true" exceptregex:"^test"`
+	Region   string        `env:"TEST_REGION" regex:"^[a-z]+-[0-9]$"`
+	Timeout  time.Duration `env:"TEST_TIMEOUT" default:"5s"`
+	Workers  *int          `env:"TEST_WORKERS"`
+	DB       bindDB        `env:"TEST_DB_"`
+	Cache    *bindDB       `env:"TEST_CACHE_"`
+	Skipped  string        `env:"-"`
+	Untagged string
+}
+
+func TestBind(t *testing.T) {
+	scenarios := []struct {
+		desc       string
+		setEnv     map[string]string
+		wantErrs   []string
+		wantConfig func(c bindConfig) bool
+	}{
+		{
+			desc:     "#00",
+			wantErrs: []string{"bindConfig.Name"},
+			wantConfig: func(c bindConfig) bool {
+				return c.Mode == "dev" && c.Timeout == 5*time.Second && c.Workers == nil &&
+					c.DB.Host == "localhost" && c.DB.Port == 5432 && c.Cache.Port == 5432
+			},
+		},
+		{
+			desc: "#01",
+			setEnv: map[string]string{
+				"TEST_NAME":       "api",
+				"TEST_MODE":       "PROD",
+				"TEST_DEBUG":      "true",
+				"TEST_REGION":     "eu-1",
+				"TEST_WORKERS":    "4",
+				"TEST_DB_HOST":    "db",
+				"TEST_CACHE_PORT": "6379",
+			},
+			wantConfig: func(c bindConfig) bool {
+				return c.Name == "api" && c.Mode == "PROD" && c.Debug && c.Region == "eu-1" &&
+					*c.Workers == 4 && c.DB.Host == "db" && c.Cache.Port == 6379
+			},
+		},
+		{
+			desc: "#02",
+			setEnv: map[string]string{
+				"TEST_NAME":    "test-api",
+				"TEST_MODE":    "staging",
+				"TEST_REGION":  "eu",
+				"TEST_WORKERS": "four",
+				"TEST_DB_PORT": "-",
+			},
+			wantErrs: []string{
+				"bindConfig.Mode",
+				"bindConfig.Name",
+				"bindConfig.Region",
+				"bindConfig.Workers",
+				"bindConfig.DB.Port",
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run("Bind", func(t *testing.T) {
+			for k, v := range s.setEnv {
+				os.Setenv(k, v)
+			}
+			defer func() {
+				for k := range s.setEnv {
+					os.Unsetenv(k)
+				}
+			}()
+
+			var c bindConfig
+			err := env.Bind(&c)
+
+			var gotErrs []string
+			var bindErr *env.BindError
+			if errors.As(err, &bindErr) {
+				for _, fe := range bindErr.Errors {
+					gotErrs = append(gotErrs, fe.Field)
+				}
+
+				var fe *env.FieldError
+				if !bindErr.As(&fe) || fe != bindErr.Errors[0] {
+					t.Errorf("%v: got As '%v' want '%v'", s.desc, fe, bindErr.Errors[0])
+				}
+			} else if err != nil {
+				t.Fatalf("%v: unexpected error '%v'", s.desc, err)
+			}
+
+			if len(gotErrs) != len(s.wantErrs) {
+				t.Fatalf("%v: got errors '%v' want '%v'", s.desc, gotErrs, s.wantErrs)
+			}
+			for i := range gotErrs {
+				if gotErrs[i] != s.wantErrs[i] {
+					t.Errorf("%v: got error '%v' want '%v'", s.desc, gotErrs[i], s.wantErrs[i])
+				}
+			}
+
+			if s.wantConfig != nil && !s.wantConfig(c) {
+				t.Errorf("%v: got config '%+v'", s.desc, c)
+			}
+		})
+	}
+}
+
+func TestBindTagLists(t *testing.T) {
+	var c struct {
+		Mode   string `env:"MODE" in:"b, a"`
+		Name   string `env:"NAME" except:"x, y"`
+		Region string `env:"REGION" glob:"us-*, eu-*"`
+		Zone   string `env:"ZONE" exceptglob:"eu-*, us-*"`
+	}
+
+	e := env.New(env.Map{"MODE": "a", "NAME": "y", "REGION": "eu-1", "ZONE": "us-1"})
+	err := e.Bind(&c)
+
+	var bindErr *env.BindError
+	if !errors.As(err, &bindErr) || len(bindErr.Errors) != 2 {
+		t.Fatalf("got '%v' want 2 errors", err)
+	}
+
+	if got := bindErr.Errors[0].Field; !strings.HasSuffix(got, ".Name") {
+		t.Errorf("got error on '%v' want 'Name'", got)
+	}
+	if got := bindErr.Errors[1].Field; !strings.HasSuffix(got, ".Zone") {
+		t.Errorf("got error on '%v' want 'Zone'", got)
+	}
+
+	if c.Mode != "a" || c.Region != "eu-1" {
+		t.Errorf("got '%+v' want Mode 'a' and Region 'eu-1'", c)
+	}
+}
+
+type bindNode struct {
+	Name string    `env:"NAME"`
+	Next *bindNode `env:"NEXT_"`
+	Same *bindNode
+}
+
+func TestBindCycle(t *testing.T) {
+	var n bindNode
+	err := env.New(env.Map{"NAME": "a", "NEXT_NAME": "b"}).Bind(&n)
+
+	var bindErr *env.BindError
+	if !errors.As(err, &bindErr) || len(bindErr.Errors) != 2 || !errors.Is(err, env.ErrCycle) {
+		t.Fatalf("got '%v' want 2 errors wrapping '%v'", err, env.ErrCycle)
+	}
+
+	if got, want := bindErr.Errors[0].Error(), "env: reference cycle: NEXT_ (env_test.bindNode -> env_test.bindNode) (field bindNode.Next)"; got != want {
+		t.Errorf("got '%v' want '%v'", got, want)
+	}
+
+	if n.Name != "a" || n.Next != nil || n.Same != nil {
+		t.Errorf("got '%+v' want Name 'a' and nil pointers", n)
+	}
+}
+
+func TestMustBind(t *testing.T) {
+	defer func() {
+		if p := recover(); p == nil {
+			t.Errorf("gotPanic '%v' wantPanic 'true'", p)
+		}
+	}()
+
+	var c bindConfig
+	env.MustBind(&c)
+}
+
+func TestBindInvalidTarget(t *testing.T) {
+	var c bindConfig
+	if err := env.Bind(c); err == nil {
+		t.Errorf("got error 'nil' want non-nil")
+	}
+}
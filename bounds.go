@@ -0,0 +1,180 @@
+package env
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// Number is the set of numeric types bounds apply to, time.Duration included.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+// Bound is a constraint on a number, built with Min, Max, MinExclusive,
+// MaxExclusive, Between, BetweenExclusive, MultipleOf or NonZero.
+// The zero Bound rejects every value.
+type Bound[T Value] struct {
+	desc string
+	ok   func(value T) bool
+}
+
+// String describes the bound, e.g. ">= 1".
+func (b Bound[T]) String() string {
+	if b.ok == nil {
+		return "invalid bound"
+	}
+
+	return b.desc
+}
+
+// Min requires the value to be greater than or equal to 'n'.
+func Min[T Number](n T) Bound[T] {
+	return Bound[T]{desc: fmt.Sprintf(">= %v", n), ok: func(v T) bool { return v >= n }}
+}
+
+// Max requires the value to be less than or equal to 'n'.
+func Max[T Number](n T) Bound[T] {
+	return Bound[T]{desc: fmt.Sprintf("<= %v", n), ok: func(v T) bool { return v <= n }}
+}
+
+// MinExclusive requires the value to be greater than 'n'.
+func MinExclusive[T Number](n T) Bound[T] {
+	return Bound[T]{desc: fmt.Sprintf("> %v", n), ok: func(v T) bool { return v > n }}
+}
+
+// MaxExclusive requires the value to be less than 'n'.
+func MaxExclusive[T Number](n T) Bound[T] {
+	return Bound[T]{desc: fmt.Sprintf("< %v", n), ok: func(v T) bool { return v < n }}
+}
+
+// Between requires the value to be between 'lo' and 'hi', both included.
+func Between[T Number](lo, hi T) Bound[T] {
+	return Bound[T]{desc: fmt.Sprintf("[%v, %v]", lo, hi), ok: func(v T) bool { return lo <= v && v <= hi }}
+}
+
+// BetweenExclusive requires the value to be between 'lo' and 'hi', both excluded.
+func BetweenExclusive[T Number](lo, hi T) Bound[T] {
+	return Bound[T]{desc: fmt.Sprintf("(%v, %v)", lo, hi), ok: func(v T) bool { return lo < v && v < hi }}
+}
+
+// MultipleOf requires the value to be a multiple of 'n'. Zero is a multiple of every number.
+// Floats are compared with a relative tolerance of 1e-6 for float32 and 1e-12 for float64,
+// so that 0.3 is a multiple of 0.1.
+func MultipleOf[T Number](n T) Bound[T] {
+	return Bound[T]{desc: fmt.Sprintf("multiple of %v", n), ok: func(v T) bool { return isMultiple(v, n) }}
+}
+
+// NonZero requires the value not to be zero.
+func NonZero[T Number]() Bound[T] {
+	var zero T
+	return Bound[T]{desc: "non-zero", ok: func(v T) bool { return v != zero }}
+}
+
+// GetWithin is like GetAsWithin but reads from the source of the Env.
+func (t Typed[T]) GetWithin(key string, defaultValue T, bounds ...Bound[T]) T {
+	value, r := t.eval(t.e.Var(key).Default(fmt.Sprint(defaultValue)), boundStrings(bounds), []func(string, T) error{checkBounds(bounds)})
+	if r.Defaulted || r.Err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
+// LookupWithin is like LookupAsWithin but reads from the source of the Env.
+func (t Typed[T]) LookupWithin(key string, bounds ...Bound[T]) (T, error) {
+	value, r := t.eval(t.e.Var(key), boundStrings(bounds), []func(string, T) error{checkBounds(bounds)})
+	if r.Err != nil {
+		var zero T
+		return zero, r.Err
+	}
+
+	return value, nil
+}
+
+// MustGetWithin is like MustGetAsWithin but reads from the source of the Env.
+func (t Typed[T]) MustGetWithin(key string, bounds ...Bound[T]) T {
+	return must(t.LookupWithin(key, bounds...))
+}
+
+// GetAsWithin returns the environment variable set in 'key' parsed as T.
+// If value is not set for 'key', can not be parsed as T or does not satisfy 'bounds', it returns 'defaultValue'.
+//
+//	workers := env.GetAsWithin("WORKERS", 4, env.Between(1, 64))
+func GetAsWithin[T Value](key string, defaultValue T, bounds ...Bound[T]) T {
+	return As[T](std).GetWithin(key, defaultValue, bounds...)
+}
+
+// LookupAsWithin returns the environment variable set in 'key' parsed as T.
+// If value is not set for 'key', can not be parsed as T or does not satisfy 'bounds', it returns an *Error.
+func LookupAsWithin[T Value](key string, bounds ...Bound[T]) (T, error) {
+	return As[T](std).LookupWithin(key, bounds...)
+}
+
+// MustGetAsWithin returns the environment variable set in 'key' parsed as T.
+// If value is not set for 'key', can not be parsed as T or does not satisfy 'bounds', it raises a panic.
+func MustGetAsWithin[T Value](key string, bounds ...Bound[T]) T {
+	return As[T](std).MustGetWithin(key, bounds...)
+}
+
+// checkBounds returns a check rejecting the values that do not satisfy every bound of 'bounds'.
+func checkBounds[T Value](bounds []Bound[T]) func(key string, value T) error {
+	return func(key string, value T) error {
+		for _, b := range bounds {
+			if b.ok == nil || !b.ok(value) {
+				return &Error{Key: key, Value: fmt.Sprint(value), Constraint: b.String(), Err: ErrOutOfRange}
+			}
+		}
+
+		return nil
+	}
+}
+
+func boundStrings[T Value](bounds []Bound[T]) []string {
+	s := make([]string, len(bounds))
+	for i, b := range bounds {
+		s[i] = b.String()
+	}
+
+	return s
+}
+
+// Relative errors allowed by MultipleOf on floats.
+const (
+	float32Tolerance = 1e-6
+	float64Tolerance = 1e-12
+)
+
+// isMultiple reports whether 'v' is a multiple of 'n'.
+func isMultiple[T Number](v, n T) bool {
+	rv, rn := reflect.ValueOf(v), reflect.ValueOf(n)
+
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if rn.Int() == 0 {
+			return rv.Int() == 0
+		}
+
+		return rv.Int()%rn.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if rn.Uint() == 0 {
+			return rv.Uint() == 0
+		}
+
+		return rv.Uint()%rn.Uint() == 0
+	}
+
+	if rn.Float() == 0 {
+		return rv.Float() == 0
+	}
+
+	tolerance := float64Tolerance
+	if rv.Type().Bits() == 32 {
+		tolerance = float32Tolerance
+	}
+
+	fv, fn := rv.Float(), rn.Float()
+	return math.Abs(fv-math.Round(fv/fn)*fn) <= tolerance*math.Max(math.Abs(fv), math.Abs(fn))
+}
@@ -0,0 +1,197 @@
+package env_test
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gomodrepo/env"
+)
+
+func TestBounds(t *testing.T) {
+	e := env.New(env.Map{
+		"WORKERS": "16",
+		"ZERO":    "0",
+		"BIG":     "100",
+		"RATIO":   "0.75",
+		"F":       "0.3",
+		"G":       "0.7",
+		"LARGE":   "300000000.3",
+		"MILLI":   "1000000.0005",
+		"TIMEOUT": "90s",
+	})
+
+	scenarios := []struct {
+		desc      string
+		lookup    func() (any, error)
+		wantValue any
+		wantErr   error
+	}{
+		{
+			desc:      "#00",
+			lookup:    func() (any, error) { return env.As[int](e).LookupWithin("WORKERS", env.Between(1, 64)) },
+			wantValue: 16,
+		},
+		{
+			desc:      "#01",
+			lookup:    func() (any, error) { return env.As[int](e).LookupWithin("BIG", env.Between(1, 64)) },
+			wantValue: 0,
+			wantErr:   env.ErrOutOfRange,
+		},
+		{
+			desc:      "#02",
+			lookup:    func() (any, error) { return env.As[int](e).LookupWithin("WORKERS", env.Min(1), env.MultipleOf(8)) },
+			wantValue: 16,
+		},
+		{
+			desc:      "#03",
+			lookup:    func() (any, error) { return env.As[int](e).LookupWithin("WORKERS", env.MultipleOf(5)) },
+			wantValue: 0,
+			wantErr:   env.ErrOutOfRange,
+		},
+		{
+			desc:      "#04",
+			lookup:    func() (any, error) { return env.As[uint](e).LookupWithin("ZERO", env.NonZero[uint]()) },
+			wantValue: uint(0),
+			wantErr:   env.ErrOutOfRange,
+		},
+		{
+			desc:      "#05",
+			lookup:    func() (any, error) { return env.As[int](e).LookupWithin("BIG", env.MaxExclusive(100)) },
+			wantValue: 0,
+			wantErr:   env.ErrOutOfRange,
+		},
+		{
+			desc:      "#06",
+			lookup:    func() (any, error) { return env.As[int](e).LookupWithin("BIG", env.Max(100)) },
+			wantValue: 100,
+		},
+		{
+			desc: "#07",
+			lookup: func() (any, error) {
+				return env.As[float64](e).LookupWithin("RATIO", env.BetweenExclusive(0.0, 1.0), env.MultipleOf(0.25))
+			},
+			wantValue: 0.75,
+		},
+		{
+			desc:      "#08",
+			lookup:    func() (any, error) { return env.As[float64](e).LookupWithin("ZERO", env.MinExclusive(0.0)) },
+			wantValue: 0.0,
+			wantErr:   env.ErrOutOfRange,
+		},
+		{
+			desc:      "#09",
+			lookup:    func() (any, error) { return env.As[time.Duration](e).LookupWithin("TIMEOUT", env.Max(time.Minute)) },
+			wantValue: time.Duration(0),
+			wantErr:   env.ErrOutOfRange,
+		},
+		{
+			desc:      "#10",
+			lookup:    func() (any, error) { return env.As[int](e).LookupWithin(_testKey, env.Min(1)) },
+			wantValue: 0,
+			wantErr:   env.ErrNotSet,
+		},
+		{
+			desc:      "#11",
+			lookup:    func() (any, error) { return env.As[int](e).LookupWithin("RATIO", env.Min(1)) },
+			wantValue: 0,
+			wantErr:   env.ErrInvalid,
+		},
+		{
+			desc:      "#12",
+			lookup:    func() (any, error) { return env.As[float64](e).LookupWithin("F", env.MultipleOf(0.1)) },
+			wantValue: 0.3,
+		},
+		{
+			desc:      "#13",
+			lookup:    func() (any, error) { return env.As[float64](e).LookupWithin("F", env.MultipleOf(0.2)) },
+			wantValue: 0.0,
+			wantErr:   env.ErrOutOfRange,
+		},
+		{
+			desc:      "#14",
+			lookup:    func() (any, error) { return env.As[int](e).LookupWithin("WORKERS", env.Bound[int]{}) },
+			wantValue: 0,
+			wantErr:   env.ErrOutOfRange,
+		},
+		{
+			desc:      "#15",
+			lookup:    func() (any, error) { return env.As[float64](e).LookupWithin("LARGE", env.MultipleOf(0.5)) },
+			wantValue: 0.0,
+			wantErr:   env.ErrOutOfRange,
+		},
+		{
+			desc:      "#16",
+			lookup:    func() (any, error) { return env.As[float64](e).LookupWithin("LARGE", env.MultipleOf(0.1)) },
+			wantValue: 300000000.3,
+		},
+		{
+			desc:      "#17",
+			lookup:    func() (any, error) { return env.As[float64](e).LookupWithin("MILLI", env.MultipleOf(0.001)) },
+			wantValue: 0.0,
+			wantErr:   env.ErrOutOfRange,
+		},
+		{
+			desc:      "#18",
+			lookup:    func() (any, error) { return env.As[float32](e).LookupWithin("F", env.MultipleOf[float32](0.1)) },
+			wantValue: float32(0.3),
+		},
+		{
+			desc:      "#19",
+			lookup:    func() (any, error) { return env.As[float32](e).LookupWithin("G", env.MultipleOf[float32](0.1)) },
+			wantValue: float32(0.7),
+		},
+		{
+			desc:      "#20",
+			lookup:    func() (any, error) { return env.As[float32](e).LookupWithin("G", env.MultipleOf[float32](0.3)) },
+			wantValue: float32(0),
+			wantErr:   env.ErrOutOfRange,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run("Bounds", func(t *testing.T) {
+			got, err := s.lookup()
+			if got != s.wantValue || !errors.Is(err, s.wantErr) {
+				t.Errorf("%v: got '%v', '%v' want '%v', '%v'", s.desc, got, err, s.wantValue, s.wantErr)
+			}
+		})
+	}
+
+	_, err := env.As[int](e).LookupWithin("BIG", env.Between(1, 64))
+	if got, want := err.Error(), `env: value is out of range: BIG: value "100", [1, 64]`; got != want {
+		t.Errorf("got '%v' want '%v'", got, want)
+	}
+}
+
+func TestGetAsWithin(t *testing.T) {
+	backup := os.Getenv(_testKey)
+	defer os.Setenv(_testKey, backup)
+
+	os.Setenv(_testKey, "128")
+
+	if got := env.GetAsWithin(_testKey, 4, env.Between(1, 64)); got != 4 {
+		t.Errorf("got '%v' want '%v'", got, 4)
+	}
+
+	if got := env.GetAsWithin(_testKey, 4, env.Min(1)); got != 128 {
+		t.Errorf("got '%v' want '%v'", got, 128)
+	}
+
+	if got, err := env.LookupAsWithin(_testKey, env.Max(64)); got != 0 || !errors.Is(err, env.ErrOutOfRange) {
+		t.Errorf("got '%v', '%v' want '%v', '%v'", got, err, 0, env.ErrOutOfRange)
+	}
+
+	if d, ok := env.DefaultRegistry.Lookup(_testKey); !ok || len(d.Range) == 0 {
+		t.Errorf("got '%+v' want a declaration with a range", d)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("got no panic")
+		}
+	}()
+
+	env.MustGetAsWithin(_testKey, env.Between(1, 64))
+}
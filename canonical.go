@@ -0,0 +1,34 @@
+package env
+
+// GetInCanonical is like the package-level GetInCanonical but reads from the source of 'e'.
+func (e *Env) GetInCanonical(key, defaultValue string, in ...string) string {
+	return e.Var(key).Default(defaultValue).OneOf(in...).CaseInsensitive().Canonical().String()
+}
+
+// LookupInCanonical is like the package-level LookupInCanonical but reads from the source of 'e'.
+func (e *Env) LookupInCanonical(key string, in ...string) (string, error) {
+	return e.Var(key).OneOf(in...).CaseInsensitive().Canonical().Lookup()
+}
+
+// MustGetInCanonical is like the package-level MustGetInCanonical but reads from the source of 'e'.
+func (e *Env) MustGetInCanonical(key string, in ...string) string {
+	return must(e.LookupInCanonical(key, in...))
+}
+
+// GetInCanonical returns the entry of 'in' equal to the environment variable set to 'key', ignoring case.
+// If value is not set for 'key' or different from 'in', it returns 'defaultValue'.
+func GetInCanonical(key, defaultValue string, in ...string) string {
+	return std.GetInCanonical(key, defaultValue, in...)
+}
+
+// LookupInCanonical returns the entry of 'in' equal to the environment variable set to 'key', ignoring case.
+// If value is not set for 'key' or different from 'in', it returns an *Error.
+func LookupInCanonical(key string, in ...string) (string, error) {
+	return std.LookupInCanonical(key, in...)
+}
+
+// MustGetInCanonical returns the entry of 'in' equal to the environment variable set to 'key', ignoring case.
+// If value is not set for 'key' or different from 'in', it raises a panic.
+func MustGetInCanonical(key string, in ...string) string {
+	return std.MustGetInCanonical(key, in...)
+}
@@ -0,0 +1,76 @@
+package env_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gomodrepo/env"
+)
+
+func TestCanonical(t *testing.T) {
+	e := env.New(env.Map{
+		"UPPER": "PROD",
+		"MIXED": "Dev",
+		"ALIAS": "Production",
+		"OTHER": "staging",
+	})
+
+	scenarios := []struct {
+		desc      string
+		v         *env.Variable
+		wantValue string
+		wantErr   error
+	}{
+		{
+			desc:      "#00",
+			v:         e.Var("UPPER").OneOf("dev", "prod").CaseInsensitive().Canonical(),
+			wantValue: "prod",
+		},
+		{
+			desc:      "#01",
+			v:         e.Var("UPPER").OneOf("dev", "prod").CaseInsensitive(),
+			wantValue: "PROD",
+		},
+		{
+			desc:      "#02",
+			v:         e.Var("ALIAS").OneOf("dev", "prod").Alias("prod", "production", "prd").CaseInsensitive().Canonical(),
+			wantValue: "prod",
+		},
+		{
+			desc:    "#03",
+			v:       e.Var("ALIAS").OneOf("dev", "prod").Alias("prod", "production", "prd"),
+			wantErr: env.ErrNotAllowed,
+		},
+		{
+			desc:      "#04",
+			v:         e.Var("ALIAS").Alias("prod", "Production"),
+			wantValue: "prod",
+		},
+		{
+			desc:    "#05",
+			v:       e.Var("OTHER").OneOf("dev", "prod").Alias("prod", "production").CaseInsensitive().Canonical(),
+			wantErr: env.ErrNotAllowed,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run("Canonical", func(t *testing.T) {
+			got, err := s.v.Lookup()
+			if got != s.wantValue || !errors.Is(err, s.wantErr) {
+				t.Errorf("%v: got '%v', '%v' want '%v', '%v'", s.desc, got, err, s.wantValue, s.wantErr)
+			}
+		})
+	}
+
+	if got := e.GetInCanonical("MIXED", "prod", "dev", "prod"); got != "dev" {
+		t.Errorf("got '%v' want '%v'", got, "dev")
+	}
+
+	if got := e.GetInCanonical("OTHER", "prod", "dev", "prod"); got != "prod" {
+		t.Errorf("got '%v' want '%v'", got, "prod")
+	}
+
+	if got := e.MustGetInCanonical("UPPER", "dev", "prod"); got != "prod" {
+		t.Errorf("got '%v' want '%v'", got, "prod")
+	}
+}
@@ -0,0 +1,195 @@
+package env
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// ValidationError reports every variable a Checker rejected.
+type ValidationError struct {
+	Errors []*Error
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+
+	return strings.Join(msgs, "\n")
+}
+
+func (e *ValidationError) Unwrap() []error {
+	return unwrapAll(e.Errors)
+}
+
+func (e *ValidationError) Is(target error) bool {
+	return isAny(e.Errors, target)
+}
+
+func (e *ValidationError) As(target any) bool {
+	return asAny(e.Errors, target)
+}
+
+// WriteTable writes the errors to 'w' as a human-readable table.
+func (e *ValidationError) WriteTable(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "KEY\tPROBLEM\tVALUE\tCONSTRAINT")
+
+	for _, err := range e.Errors {
+		value := ""
+		if err.Err != ErrNotSet && err.Err != ErrUnknown {
+			value = fmt.Sprintf("%q", err.Value)
+		}
+
+		c := err.Constraint
+		if err.Suggestion != "" {
+			c = strings.TrimSpace(fmt.Sprintf("%s (did you mean %s?)", c, err.Suggestion))
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", err.Key, err.reason(), value, c)
+	}
+
+	return tw.Flush()
+}
+
+// Table returns the errors as a human-readable table.
+func (e *ValidationError) Table() string {
+	var b strings.Builder
+	e.WriteTable(&b)
+
+	return b.String()
+}
+
+// Checker runs Must-style checks without panicking and collects every failure,
+// so that all the configuration problems can be reported at once.
+//
+//	c := env.NewChecker()
+//	host := c.Require("DB_HOST")
+//	mode := c.RequireIn("MODE", "dev", "prod")
+//	if err := c.Err(); err != nil {
+//		log.Fatal(err)
+//	}
+//
+// A Checker is not safe for concurrent use.
+type Checker struct {
+	e    *Env
+	errs []*Error
+}
+
+// NewChecker returns a Checker reading variables from the source of 'e'.
+func (e *Env) NewChecker() *Checker {
+	return &Checker{e: e}
+}
+
+// NewChecker returns a Checker reading variables from the environment.
+func NewChecker() *Checker {
+	return std.NewChecker()
+}
+
+// Check evaluates 'v' as in Variable.MustString and returns its value.
+// If the value is rejected, the error is recorded and it returns the empty string.
+func (c *Checker) Check(v *Variable) string {
+	value, err := v.Lookup()
+	if err != nil {
+		c.record(err)
+		return ""
+	}
+
+	return value
+}
+
+// Require is like MustGet but records the error instead of raising a panic.
+func (c *Checker) Require(key string) string {
+	return c.Check(c.e.Var(key))
+}
+
+// RequireIn is like MustGetIn but records the error instead of raising a panic.
+func (c *Checker) RequireIn(key string, in ...string) string {
+	return c.Check(c.e.Var(key).OneOf(in...))
+}
+
+// RequireInCaseInsensitive is like MustGetInCaseInsensitive but records the error instead of raising a panic.
+func (c *Checker) RequireInCaseInsensitive(key string, in ...string) string {
+	return c.Check(c.e.Var(key).OneOf(in...).CaseInsensitive())
+}
+
+// RequireInRegex is like MustGetInRegex but records the error instead of raising a panic.
+func (c *Checker) RequireInRegex(key string, regex ...string) string {
+	return c.Check(c.e.Var(key).Match(regex...))
+}
+
+// RequireExcept is like MustGetExcept but records the error instead of raising a panic.
+func (c *Checker) RequireExcept(key string, except ...string) string {
+	return c.Check(c.e.Var(key).NotOneOf(except...))
+}
+
+// RequireExceptCaseInsensitive is like MustGetExceptCaseInsensitive but records the error instead of raising a panic.
+func (c *Checker) RequireExceptCaseInsensitive(key string, except ...string) string {
+	return c.Check(c.e.Var(key).NotOneOf(except...).CaseInsensitive())
+}
+
+// RequireExceptRegex is like MustGetExceptRegex but records the error instead of raising a panic.
+func (c *Checker) RequireExceptRegex(key string, regex ...string) string {
+	return c.Check(c.e.Var(key).NotMatch(regex...))
+}
+
+// RequireInGlob is like MustGetInGlob but records the error instead of raising a panic.
+func (c *Checker) RequireInGlob(key string, glob ...string) string {
+	return c.Check(c.e.Var(key).Glob(glob...))
+}
+
+// RequireExceptGlob is like MustGetExceptGlob but records the error instead of raising a panic.
+func (c *Checker) RequireExceptGlob(key string, glob ...string) string {
+	return c.Check(c.e.Var(key).NotGlob(glob...))
+}
+
+// RequireNonEmpty is like MustGetNonEmpty but records the error instead of raising a panic.
+func (c *Checker) RequireNonEmpty(key string) string {
+	return c.Check(c.e.Var(key).NonEmpty())
+}
+
+// RejectUnknown records an error for every variable reported by Env.Unknown.
+// It should be called after every variable was checked or bound.
+func (c *Checker) RejectUnknown() {
+	for _, key := range c.e.Unknown() {
+		c.errs = append(c.errs, c.e.unknown(key))
+	}
+}
+
+// Bind is like MustBind but records the errors of every field instead of raising a panic.
+func (c *Checker) Bind(v any) {
+	if err := c.e.Bind(v); err != nil {
+		c.record(err)
+	}
+}
+
+// Err returns a *ValidationError listing every failed check, or nil if all of them passed.
+func (c *Checker) Err() error {
+	if len(c.errs) == 0 {
+		return nil
+	}
+
+	return &ValidationError{Errors: append([]*Error(nil), c.errs...)}
+}
+
+func (c *Checker) record(err error) {
+	var be *BindError
+	if errors.As(err, &be) {
+		for _, fe := range be.Errors {
+			c.record(fe.Err)
+		}
+
+		return
+	}
+
+	var e *Error
+	if !errors.As(err, &e) {
+		e = &Error{Err: err}
+	}
+
+	c.errs = append(c.errs, e)
+}
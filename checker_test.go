@@ -0,0 +1,73 @@
+package env_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gomodrepo/env"
+)
+
+func TestChecker(t *testing.T) {
+	e := env.New(env.Map{
+		"MODE":   "staging",
+		"NAME":   "test-api",
+		"REGION": "eu-1",
+		"PORT":   "http",
+	})
+
+	c := e.NewChecker()
+
+	got := []string{
+		c.Require("DB_HOST"),
+		c.RequireIn("MODE", "dev", "prod"),
+		c.RequireInCaseInsensitive("MODE", "STAGING"),
+		c.RequireInRegex("REGION", "^eu-"),
+		c.RequireExcept("REGION", "us-1"),
+		c.RequireExceptCaseInsensitive("NAME", "TEST-API"),
+		c.RequireExceptRegex("NAME", "^test"),
+		c.Check(e.Var("TIMEOUT").Default("5s")),
+	}
+	want := []string{"", "", "staging", "eu-1", "eu-1", "", "", "5s"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("#%02d: got '%v' want '%v'", i, got[i], want[i])
+		}
+	}
+
+	var cfg struct {
+		Port int `env:"PORT"`
+	}
+	c.Bind(&cfg)
+
+	err := c.Err()
+
+	var ve *env.ValidationError
+	if !errors.As(err, &ve) || len(ve.Errors) != 5 {
+		t.Fatalf("got error '%v' want 5 errors", err)
+	}
+	if !errors.Is(err, env.ErrNotSet) || !errors.Is(err, env.ErrInvalid) {
+		t.Errorf("got error '%v' want ErrNotSet and ErrInvalid", err)
+	}
+	if !ve.Is(env.ErrNotSet) || ve.Is(env.ErrCycle) {
+		t.Errorf("got Is '%v' '%v' want 'true' 'false'", ve.Is(env.ErrNotSet), ve.Is(env.ErrCycle))
+	}
+	var first *env.Error
+	if !ve.As(&first) || first != ve.Errors[0] {
+		t.Errorf("got As '%v' want '%v'", first, ve.Errors[0])
+	}
+
+	wantTable := "" +
+		"KEY      PROBLEM              VALUE       CONSTRAINT\n" +
+		"DB_HOST  can not find key                 \n" +
+		"MODE     value is not in      \"staging\"   in [\"dev\" \"prod\"]\n" +
+		"NAME     value is not except  \"test-api\"  except-case-insensitive [\"TEST-API\"]\n" +
+		"NAME     value is not except  \"test-api\"  except-regex [\"^test\"]\n" +
+		"PORT     can not parse value  \"http\"      type int\n"
+	if got := ve.Table(); got != wantTable {
+		t.Errorf("got table\n%v\nwant\n%v", got, wantTable)
+	}
+
+	if err := env.New(env.Map{}).NewChecker().Err(); err != nil {
+		t.Errorf("got error '%v' want 'nil'", err)
+	}
+}
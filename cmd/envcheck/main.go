@@ -0,0 +1,256 @@
+// Command envcheck validates an environment against a schema.
+//
+// Usage:
+//
+//	envcheck -schema schema.json [-dotenv file.env]
+//	envcheck export [-format dotenv|json|shell|docker|systemd] [-schema schema.json] [-dotenv file.env] [KEY...]
+//
+// The schema is a JSON document listing the variables and their constraints:
+//
+//	{
+//	  "variables": [
+//	    {"key": "MODE", "required": true, "in": ["dev", "prod"]},
+//	    {"key": "REGION", "regex": ["^eu-"], "except_regex": ["-test$"]}
+//	  ]
+//	}
+//
+// The supported constraints are those of the env package: "required",
+// "non_empty", "in", "in_case_insensitive", "regex", "glob", "except",
+// "except_case_insensitive", "except_regex" and "except_glob". Constraints of
+// a variable that is not set and not required are ignored.
+//
+// envcheck validates the process environment, or the dotenv file given with
+// -dotenv, and exits with status 1 after reporting every violation. It exits
+// with status 2 if the schema or the dotenv file can not be read.
+//
+// The export subcommand writes the variables KEY, or the variables of the
+// schema, or every variable if neither is given, in the requested format,
+// dotenv by default. Variables that are not set are omitted.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/gomodrepo/env"
+)
+
+// Schema lists the variables an environment must provide.
+type Schema struct {
+	Variables []Variable `json:"variables"`
+}
+
+// Variable is a variable of a Schema and its constraints.
+type Variable struct {
+	Key                   string   `json:"key"`
+	Description           string   `json:"description,omitempty"`
+	Required              bool     `json:"required,omitempty"`
+	NonEmpty              bool     `json:"non_empty,omitempty"`
+	In                    []string `json:"in,omitempty"`
+	InCaseInsensitive     []string `json:"in_case_insensitive,omitempty"`
+	Regex                 []string `json:"regex,omitempty"`
+	Except                []string `json:"except,omitempty"`
+	ExceptCaseInsensitive []string `json:"except_case_insensitive,omitempty"`
+	ExceptRegex           []string `json:"except_regex,omitempty"`
+	Glob                  []string `json:"glob,omitempty"`
+	ExceptGlob            []string `json:"except_glob,omitempty"`
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) > 0 && args[0] == "export" {
+		return runExport(args[1:], stdout, stderr)
+	}
+
+	fs := flag.NewFlagSet("envcheck", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	schemaFile := fs.String("schema", "", "JSON schema listing the variables to check")
+	dotenvFile := fs.String("dotenv", "", "dotenv file to check instead of the process environment")
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if *schemaFile == "" {
+		fmt.Fprintln(stderr, "envcheck: -schema is required")
+		fs.Usage()
+		return 2
+	}
+
+	schema, err := readSchema(*schemaFile)
+	if err != nil {
+		fmt.Fprintln(stderr, "envcheck:", err)
+		return 2
+	}
+
+	src, err := source(*dotenvFile)
+	if err != nil {
+		fmt.Fprintln(stderr, "envcheck:", err)
+		return 2
+	}
+
+	err = check(env.New(src), schema)
+
+	var ve *env.ValidationError
+	if errors.As(err, &ve) {
+		fmt.Fprintf(stdout, "%d violation(s):\n\n", len(ve.Errors))
+		ve.WriteTable(stdout)
+		return 1
+	}
+
+	fmt.Fprintf(stdout, "ok: %d variable(s) checked\n", len(schema.Variables))
+	return 0
+}
+
+func runExport(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("envcheck export", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	format := fs.String("format", "dotenv", "output format: dotenv, json, shell, docker or systemd")
+	schemaFile := fs.String("schema", "", "JSON schema listing the variables to export")
+	dotenvFile := fs.String("dotenv", "", "dotenv file to export instead of the process environment")
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	f, err := env.ParseFormat(*format)
+	if err != nil {
+		fmt.Fprintln(stderr, "envcheck:", err)
+		return 2
+	}
+
+	src, err := source(*dotenvFile)
+	if err != nil {
+		fmt.Fprintln(stderr, "envcheck:", err)
+		return 2
+	}
+
+	keys := fs.Args()
+	if len(keys) == 0 && *schemaFile != "" {
+		schema, err := readSchema(*schemaFile)
+		if err != nil {
+			fmt.Fprintln(stderr, "envcheck:", err)
+			return 2
+		}
+
+		for _, v := range schema.Variables {
+			keys = append(keys, v.Key)
+		}
+	}
+
+	if k, ok := src.(env.Keyer); ok && len(keys) == 0 {
+		for _, key := range k.Keys() {
+			if !f.CanExport(key) {
+				fmt.Fprintf(stderr, "envcheck: skipping %q: invalid %v key\n", key, f)
+				continue
+			}
+
+			keys = append(keys, key)
+		}
+	}
+
+	m, err := env.New(src).Collect(keys...)
+	if err == nil {
+		err = env.Export(stdout, f, m)
+	}
+
+	if err != nil {
+		fmt.Fprintln(stderr, "envcheck:", err)
+		return 1
+	}
+
+	return 0
+}
+
+// source returns the variables of the dotenv file 'name', or of the process environment if it is empty.
+func source(name string) (env.Source, error) {
+	if name == "" {
+		return env.OS, nil
+	}
+
+	return env.ParseFile(name)
+}
+
+func readSchema(name string) (*Schema, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	dec := json.NewDecoder(f)
+	dec.DisallowUnknownFields()
+
+	var schema Schema
+	if err := dec.Decode(&schema); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	for i, v := range schema.Variables {
+		if v.Key == "" {
+			return nil, fmt.Errorf("%s: variable %d has no key", name, i)
+		}
+	}
+
+	return &schema, nil
+}
+
+// check validates 'e' against 'schema' and returns a *env.ValidationError listing every violation.
+func check(e *env.Env, schema *Schema) error {
+	c := e.NewChecker()
+
+	for _, v := range schema.Variables {
+		if _, ok := e.Source().Lookup(v.Key); !ok {
+			if v.Required {
+				c.Require(v.Key)
+			}
+
+			continue
+		}
+
+		if v.NonEmpty {
+			c.RequireNonEmpty(v.Key)
+		}
+
+		if len(v.In) > 0 {
+			c.RequireIn(v.Key, v.In...)
+		}
+
+		if len(v.InCaseInsensitive) > 0 {
+			c.RequireInCaseInsensitive(v.Key, v.InCaseInsensitive...)
+		}
+
+		if len(v.Regex) > 0 {
+			c.RequireInRegex(v.Key, v.Regex...)
+		}
+
+		if len(v.Except) > 0 {
+			c.RequireExcept(v.Key, v.Except...)
+		}
+
+		if len(v.ExceptCaseInsensitive) > 0 {
+			c.RequireExceptCaseInsensitive(v.Key, v.ExceptCaseInsensitive...)
+		}
+
+		if len(v.ExceptRegex) > 0 {
+			c.RequireExceptRegex(v.Key, v.ExceptRegex...)
+		}
+
+		if len(v.Glob) > 0 {
+			c.RequireInGlob(v.Key, v.Glob...)
+		}
+
+		if len(v.ExceptGlob) > 0 {
+			c.RequireExceptGlob(v.Key, v.ExceptGlob...)
+		}
+	}
+
+	return c.Err()
+}
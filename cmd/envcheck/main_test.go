@@ -0,0 +1,128 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	scenarios := []struct {
+		desc       string
+		args       []string
+		wantCode   int
+		wantStdout []string
+	}{
+		{
+			desc:       "#00",
+			args:       []string{"-schema", "testdata/schema.json", "-dotenv", "testdata/valid.env"},
+			wantCode:   0,
+			wantStdout: []string{"ok: 6 variable(s) checked"},
+		},
+		{
+			desc:     "#01",
+			args:     []string{"-schema", "testdata/schema.json", "-dotenv", "testdata/invalid.env"},
+			wantCode: 1,
+			wantStdout: []string{
+				"5 violation(s)",
+				"MODE     value is not in",
+				"LEVEL    value is not in",
+				"REGION   value is not except",
+				"NAME     value is not except",
+				"DB_HOST  can not find key",
+			},
+		},
+		{
+			desc:     "#02",
+			args:     []string{"-dotenv", "testdata/valid.env"},
+			wantCode: 2,
+		},
+		{
+			desc:     "#03",
+			args:     []string{"-schema", "testdata/missing.json"},
+			wantCode: 2,
+		},
+		{
+			desc:     "#04",
+			args:     []string{"-schema", "testdata/schema.json", "-dotenv", "testdata/missing.env"},
+			wantCode: 2,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run("Run", func(t *testing.T) {
+			var stdout, stderr strings.Builder
+
+			code := run(s.args, &stdout, &stderr)
+			if code != s.wantCode {
+				t.Errorf("%v: got code '%v' want '%v' (stderr: %v)", s.desc, code, s.wantCode, stderr.String())
+			}
+
+			for _, want := range s.wantStdout {
+				if !strings.Contains(stdout.String(), want) {
+					t.Errorf("%v: got stdout\n%v\nwant it to contain '%v'", s.desc, stdout.String(), want)
+				}
+			}
+		})
+	}
+}
+
+func TestRunExport(t *testing.T) {
+	scenarios := []struct {
+		desc       string
+		args       []string
+		wantCode   int
+		wantStdout string
+		wantStderr string
+	}{
+		{
+			desc:       "#00",
+			args:       []string{"export", "-dotenv", "testdata/valid.env", "MODE", "NAME", "MISSING"},
+			wantStdout: "MODE=prod\nNAME=api\n",
+		},
+		{
+			desc:       "#01",
+			args:       []string{"export", "-format", "shell", "-dotenv", "testdata/invalid.env"},
+			wantStdout: "export LEVEL='trace'\nexport MODE='staging'\nexport NAME='Admin'\nexport REGION='eu-test'\n",
+		},
+		{
+			desc:       "#02",
+			args:       []string{"export", "-format", "systemd", "-schema", "testdata/schema.json", "-dotenv", "testdata/valid.env"},
+			wantStdout: "Environment=\"DB_HOST=db\"\nEnvironment=\"LEVEL=INFO\"\nEnvironment=\"MODE=prod\"\nEnvironment=\"NAME=api\"\nEnvironment=\"REGION=eu-west\"\n",
+		},
+		{
+			desc:     "#03",
+			args:     []string{"export", "-format", "yaml"},
+			wantCode: 2,
+		},
+		{
+			desc:       "#04",
+			args:       []string{"export", "-dotenv", "testdata/dotted.env"},
+			wantStdout: "FOO=bar\napp.name=x\n",
+		},
+		{
+			desc:       "#05",
+			args:       []string{"export", "-format", "shell", "-dotenv", "testdata/dotted.env"},
+			wantStdout: "export FOO='bar'\n",
+			wantStderr: "envcheck: skipping \"app.name\": invalid shell key\n",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run("RunExport", func(t *testing.T) {
+			var stdout, stderr strings.Builder
+
+			code := run(s.args, &stdout, &stderr)
+			if code != s.wantCode {
+				t.Errorf("%v: got code '%v' want '%v' (stderr: %v)", s.desc, code, s.wantCode, stderr.String())
+			}
+
+			if stdout.String() != s.wantStdout {
+				t.Errorf("%v: got stdout\n%v\nwant\n%v", s.desc, stdout.String(), s.wantStdout)
+			}
+
+			if s.wantCode == 0 && stderr.String() != s.wantStderr {
+				t.Errorf("%v: got stderr\n%v\nwant\n%v", s.desc, stderr.String(), s.wantStderr)
+			}
+		})
+	}
+}
@@ -0,0 +1,332 @@
+package env
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ParseError reports a syntax error in a dotenv file.
+type ParseError struct {
+	// File is the name of the file, empty if the content was not read from a file.
+	File string
+	// Line and Column are the 1-based position of the error.
+	Line, Column int
+	// Msg describes the error.
+	Msg string
+}
+
+func (e *ParseError) Error() string {
+	file := e.File
+	if file == "" {
+		file = "<input>"
+	}
+
+	return fmt.Sprintf("env: %s:%d:%d: %s", file, e.Line, e.Column, e.Msg)
+}
+
+// Parse parses the dotenv content read from 'r'.
+//
+// Each line holds a KEY=value assignment, optionally preceded by 'export'.
+// Blank lines and lines starting with '#' are ignored. Values can be:
+//
+//	unquoted   trimmed, and ended by a '#' preceded by a space
+//	'single'   taken literally
+//	`backtick` taken literally
+//	"double"   supporting the escape sequences \n \r \t \b \f \v \\ \" \' \` and \$
+//
+// Quoted values can span several lines. When a key is assigned more than once,
+// the last assignment wins.
+func Parse(r io.Reader) (Map, error) {
+	return parseDotenv("", r)
+}
+
+// ParseFile parses the dotenv file 'name'. See Parse for the syntax.
+func ParseFile(name string) (Map, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return parseDotenv(name, f)
+}
+
+// Load sets the variables of the dotenv files 'filenames' in the process environment.
+// Variables that are already set are not overridden.
+// If no file is given, it loads ".env".
+func Load(filenames ...string) error {
+	return load(false, filenames)
+}
+
+// Overload is like Load but overrides the variables that are already set.
+func Overload(filenames ...string) error {
+	return load(true, filenames)
+}
+
+func load(override bool, filenames []string) error {
+	if len(filenames) == 0 {
+		filenames = []string{".env"}
+	}
+
+	for _, name := range filenames {
+		m, err := ParseFile(name)
+		if err != nil {
+			return err
+		}
+
+		for _, k := range m.Keys() {
+			if _, ok := os.LookupEnv(k); ok && !override {
+				continue
+			}
+
+			if err := os.Setenv(k, m[k]); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func parseDotenv(name string, r io.Reader) (Map, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &dotenvParser{name: name, src: []rune(string(b)), line: 1, col: 1}
+	m := Map{}
+
+	for {
+		p.skipSpace()
+		if p.eof() {
+			return m, nil
+		}
+
+		switch p.peek() {
+		case '\n':
+			p.next()
+			continue
+		case '#':
+			p.skipLine()
+			continue
+		}
+
+		key, err := p.key()
+		if err != nil {
+			return nil, err
+		}
+
+		if key == "export" && isSpace(p.peek()) {
+			p.skipSpace()
+			if key, err = p.key(); err != nil {
+				return nil, err
+			}
+		}
+
+		p.skipSpace()
+		if p.peek() != '=' {
+			return nil, p.errorf("expected '=' after key %q", key)
+		}
+		p.next()
+		p.skipSpace()
+
+		value, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+
+		m[key] = value
+	}
+}
+
+type dotenvParser struct {
+	name      string
+	src       []rune
+	pos       int
+	line, col int
+}
+
+func (p *dotenvParser) eof() bool {
+	return p.pos >= len(p.src)
+}
+
+func (p *dotenvParser) peek() rune {
+	if p.eof() {
+		return 0
+	}
+
+	return p.src[p.pos]
+}
+
+func (p *dotenvParser) next() rune {
+	r := p.src[p.pos]
+	p.pos++
+
+	if r == '\n' {
+		p.line++
+		p.col = 1
+	} else {
+		p.col++
+	}
+
+	return r
+}
+
+func (p *dotenvParser) errorf(format string, args ...any) error {
+	return &ParseError{File: p.name, Line: p.line, Column: p.col, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *dotenvParser) skipSpace() {
+	for !p.eof() && isSpace(p.peek()) {
+		p.next()
+	}
+}
+
+func (p *dotenvParser) skipLine() {
+	for !p.eof() && p.next() != '\n' {
+	}
+}
+
+// endLine consumes the rest of a line after a quoted value, allowing only a comment.
+func (p *dotenvParser) endLine() error {
+	p.skipSpace()
+
+	switch p.peek() {
+	case 0, '\n':
+	case '#':
+		p.skipLine()
+		return nil
+	default:
+		return p.errorf("unexpected character %q after quoted value", p.peek())
+	}
+
+	if !p.eof() {
+		p.next()
+	}
+
+	return nil
+}
+
+func (p *dotenvParser) key() (string, error) {
+	start := p.pos
+	for !p.eof() && isKeyRune(p.peek(), p.pos == start) {
+		p.next()
+	}
+
+	if p.pos == start {
+		return "", p.errorf("invalid character %q in key", p.peek())
+	}
+
+	return string(p.src[start:p.pos]), nil
+}
+
+func (p *dotenvParser) value() (string, error) {
+	switch q := p.peek(); q {
+	case '\'', '`':
+		return p.rawQuoted(q)
+	case '"':
+		return p.doubleQuoted()
+	}
+
+	var b strings.Builder
+	prev := ' '
+	for !p.eof() && p.peek() != '\n' {
+		r := p.next()
+		if r == '#' && isSpace(prev) {
+			p.skipLine()
+			return strings.TrimRight(b.String(), " \t\r"), nil
+		}
+
+		b.WriteRune(r)
+		prev = r
+	}
+
+	if !p.eof() {
+		p.next()
+	}
+
+	return strings.TrimRight(b.String(), " \t\r"), nil
+}
+
+func (p *dotenvParser) rawQuoted(q rune) (string, error) {
+	line, col := p.line, p.col
+	p.next()
+
+	start := p.pos
+	for !p.eof() && p.peek() != q {
+		p.next()
+	}
+
+	if p.eof() {
+		return "", &ParseError{File: p.name, Line: line, Column: col, Msg: fmt.Sprintf("unterminated %c-quoted value", q)}
+	}
+
+	value := string(p.src[start:p.pos])
+	p.next()
+
+	return value, p.endLine()
+}
+
+func (p *dotenvParser) doubleQuoted() (string, error) {
+	line, col := p.line, p.col
+	p.next()
+
+	var b strings.Builder
+	for {
+		if p.eof() {
+			return "", &ParseError{File: p.name, Line: line, Column: col, Msg: "unterminated \"-quoted value"}
+		}
+
+		r := p.next()
+		switch r {
+		case '"':
+			return b.String(), p.endLine()
+		case '\\':
+			if p.eof() {
+				continue
+			}
+
+			esc, ok := dotenvEscapes[p.peek()]
+			if !ok {
+				return "", p.errorf("unknown escape sequence \\%c", p.peek())
+			}
+
+			p.next()
+			b.WriteRune(esc)
+		default:
+			b.WriteRune(r)
+		}
+	}
+}
+
+var dotenvEscapes = map[rune]rune{
+	'n':  '\n',
+	'r':  '\r',
+	't':  '\t',
+	'b':  '\b',
+	'f':  '\f',
+	'v':  '\v',
+	'\\': '\\',
+	'"':  '"',
+	'\'': '\'',
+	'`':  '`',
+	'$':  '$',
+}
+
+func isSpace(r rune) bool {
+	return r == ' ' || r == '\t' || r == '\r'
+}
+
+func isKeyRune(r rune, first bool) bool {
+	switch {
+	case r == '_', 'a' <= r && r <= 'z', 'A' <= r && r <= 'Z':
+		return true
+	case '0' <= r && r <= '9', r == '.', r == '-':
+		return !first
+	}
+
+	return false
+}
@@ -0,0 +1,165 @@
+package env_test
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gomodrepo/env"
+)
+
+func TestParse(t *testing.T) {
+	scenarios := []struct {
+		desc      string
+		in        string
+		want      env.Map
+		wantLine  int
+		wantCol   int
+		wantError bool
+	}{
+		{
+			desc: "#00",
+			in:   "",
+			want: env.Map{},
+		},
+		{
+			desc: "#01",
+			in:   "A=1\n\n# comment\nexport B = 2 \n",
+			want: env.Map{"A": "1", "B": "2"},
+		},
+		{
+			desc: "#02",
+			in:   "A=x # comment\nB=x#y\nC=",
+			want: env.Map{"A": "x", "B": "x#y", "C": ""},
+		},
+		{
+			desc: "#03",
+			in:   "A='a\\n$B' # comment\nB=`a\\n\"b\"`\nC=\"a\\n\\\"b\\\"\\$\"",
+			want: env.Map{"A": "a\\n$B", "B": "a\\n\"b\"", "C": "a\n\"b\"$"},
+		},
+		{
+			desc: "#04",
+			in:   "A=\"1\n2\"\r\nB='3\n4'\n",
+			want: env.Map{"A": "1\n2", "B": "3\n4"},
+		},
+		{
+			desc: "#05",
+			in:   "export=1\nA.B-C=2",
+			want: env.Map{"export": "1", "A.B-C": "2"},
+		},
+		{
+			desc:      "#06",
+			in:        "A=1\nB 2",
+			wantLine:  2,
+			wantCol:   3,
+			wantError: true,
+		},
+		{
+			desc:      "#07",
+			in:        "A=1\n  B=\"2\n",
+			wantLine:  2,
+			wantCol:   5,
+			wantError: true,
+		},
+		{
+			desc:      "#08",
+			in:        "A=\"\\q\"",
+			wantLine:  1,
+			wantCol:   5,
+			wantError: true,
+		},
+		{
+			desc:      "#09",
+			in:        "A='1' 2",
+			wantLine:  1,
+			wantCol:   7,
+			wantError: true,
+		},
+		{
+			desc:      "#10",
+			in:        "1A=1",
+			wantLine:  1,
+			wantCol:   1,
+			wantError: true,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run("Parse", func(t *testing.T) {
+			got, err := env.Parse(strings.NewReader(s.in))
+			if s.wantError {
+				var pe *env.ParseError
+				if !errors.As(err, &pe) || pe.Line != s.wantLine || pe.Column != s.wantCol {
+					t.Errorf("%v: got error '%v' want position %v:%v", s.desc, err, s.wantLine, s.wantCol)
+				}
+				return
+			}
+
+			if err != nil || !reflect.DeepEqual(got, s.want) {
+				t.Errorf("%v: got '%v' '%v' want '%v'", s.desc, got, err, s.want)
+			}
+		})
+	}
+}
+
+func TestParseFile(t *testing.T) {
+	got, err := env.ParseFile("testdata/valid.env")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := env.Map{
+		"DB_HOST":  "localhost",
+		"DB_PORT":  "5433",
+		"EMPTY":    "",
+		"HASH":     "a#b",
+		"SINGLE":   `literal \n ${X}`,
+		"BACKTICK": `say "hi"`,
+		"DOUBLE":   "tab\there \"quoted\"",
+		"MULTI":    "line 1\nline 2",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got '%v' want '%v'", got, want)
+	}
+
+	if v := env.New(got).MustGetIn("DB_HOST", "localhost"); v != "localhost" {
+		t.Errorf("got '%v' want 'localhost'", v)
+	}
+
+	_, err = env.ParseFile("testdata/invalid.env")
+	if err == nil || !strings.HasPrefix(err.Error(), "env: testdata/invalid.env:2:5: ") {
+		t.Errorf("got error '%v'", err)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	os.Setenv("DB_HOST", "db")
+	defer func() {
+		for _, k := range []string{"DB_HOST", "DB_PORT", "EMPTY", "HASH", "SINGLE", "BACKTICK", "DOUBLE", "MULTI"} {
+			os.Unsetenv(k)
+		}
+	}()
+
+	if err := env.Load("testdata/valid.env"); err != nil {
+		t.Fatal(err)
+	}
+	if got := os.Getenv("DB_HOST"); got != "db" {
+		t.Errorf("got '%v' want 'db'", got)
+	}
+	if got := os.Getenv("DB_PORT"); got != "5433" {
+		t.Errorf("got '%v' want '5433'", got)
+	}
+
+	if err := env.Overload("testdata/valid.env"); err != nil {
+		t.Fatal(err)
+	}
+	if got := os.Getenv("DB_HOST"); got != "localhost" {
+		t.Errorf("got '%v' want 'localhost'", got)
+	}
+
+	if err := env.Load("testdata/missing.env"); err == nil {
+		t.Errorf("got error 'nil' want non-nil")
+	}
+}
@@ -0,0 +1,46 @@
+package env
+
+import "strings"
+
+// WithEmptyAsUnset treats empty values and values made only of spaces as not set,
+// so that KEY= falls back to the default value in the Get functions and is
+// reported as ErrNotSet by the Lookup and MustGet functions.
+func WithEmptyAsUnset() Option {
+	return func(e *Env) {
+		e.emptyAsUnset = true
+	}
+}
+
+// LookupNonEmpty is like the package-level LookupNonEmpty but reads from the source of 'e'.
+func (e *Env) LookupNonEmpty(key string) (string, error) {
+	return e.Var(key).NonEmpty().Lookup()
+}
+
+// MustGetNonEmpty is like the package-level MustGetNonEmpty but reads from the source of 'e'.
+func (e *Env) MustGetNonEmpty(key string) string {
+	return must(e.LookupNonEmpty(key))
+}
+
+// LookupNonEmpty returns the environment variable set to 'key'.
+// If value is not set for 'key' or is empty or made only of spaces, it returns an *Error.
+func LookupNonEmpty(key string) (string, error) {
+	return std.LookupNonEmpty(key)
+}
+
+// MustGetNonEmpty returns the environment variable set to 'key'.
+// If value is not set for 'key' or is empty or made only of spaces, it raises a panic.
+func MustGetNonEmpty(key string) string {
+	return std.MustGetNonEmpty(key)
+}
+
+func checkNonEmpty(key, value string) error {
+	if isBlank(value) {
+		return &Error{Key: key, Value: value, Constraint: "non-empty", Err: ErrEmpty}
+	}
+
+	return nil
+}
+
+func isBlank(value string) bool {
+	return strings.TrimSpace(value) == ""
+}
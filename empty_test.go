@@ -0,0 +1,75 @@
+package env_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gomodrepo/env"
+)
+
+func TestEmptyAsUnset(t *testing.T) {
+	src := env.Map{"PORT": "", "HOST": "  ", "MODE": "prod"}
+	e := env.New(src, env.WithEmptyAsUnset())
+
+	if got := e.Get("PORT", "8080"); got != "8080" {
+		t.Errorf("got '%v' want '%v'", got, "8080")
+	}
+
+	if got := e.GetIn("HOST", "localhost", "localhost", "db"); got != "localhost" {
+		t.Errorf("got '%v' want '%v'", got, "localhost")
+	}
+
+	if got := e.GetInt("PORT", 8080); got != 8080 {
+		t.Errorf("got '%v' want '%v'", got, 8080)
+	}
+
+	if got := e.GetExceptRegex("MODE", "dev", `^test`); got != "prod" {
+		t.Errorf("got '%v' want '%v'", got, "prod")
+	}
+
+	if _, err := e.Lookup("HOST"); !errors.Is(err, env.ErrNotSet) {
+		t.Errorf("got '%v' want '%v'", err, env.ErrNotSet)
+	}
+
+	plain := env.New(src)
+	if got := plain.Get("PORT", "8080"); got != "" {
+		t.Errorf("got '%v' want '%v'", got, "")
+	}
+
+	if got := plain.Var("PORT").Default("8080").EmptyAsUnset().String(); got != "8080" {
+		t.Errorf("got '%v' want '%v'", got, "8080")
+	}
+}
+
+func TestNonEmpty(t *testing.T) {
+	e := env.New(env.Map{"PORT": "", "HOST": "  ", "MODE": "prod"})
+
+	scenarios := []struct {
+		desc      string
+		key       string
+		wantValue string
+		wantErr   error
+	}{
+		{desc: "#00", key: "MODE", wantValue: "prod"},
+		{desc: "#01", key: "PORT", wantErr: env.ErrEmpty},
+		{desc: "#02", key: "HOST", wantErr: env.ErrEmpty},
+		{desc: "#03", key: _testKey, wantErr: env.ErrNotSet},
+	}
+
+	for _, s := range scenarios {
+		t.Run("NonEmpty", func(t *testing.T) {
+			got, err := e.LookupNonEmpty(s.key)
+			if got != s.wantValue || !errors.Is(err, s.wantErr) {
+				t.Errorf("%v: got '%v', '%v' want '%v', '%v'", s.desc, got, err, s.wantValue, s.wantErr)
+			}
+
+			defer func() {
+				if r := recover(); (r != nil) != (s.wantErr != nil) {
+					t.Errorf("%v: got panic '%v'", s.desc, r)
+				}
+			}()
+
+			e.MustGetNonEmpty(s.key)
+		})
+	}
+}
@@ -2,243 +2,443 @@
 package env
 
 import (
-	"os"
-	"regexp"
+	"fmt"
 	"strings"
+	"sync"
 )
 
-// Get returns the environment variable set in 'key'.
-// If value is not set for 'key', it returns 'defaultValue'.
-func Get(key, defaultValue string) string {
-	value, ok := os.LookupEnv(key)
-	if !ok {
-		return defaultValue
+// Env reads variables from a Source.
+// The package-level functions use a default Env backed by the process environment.
+type Env struct {
+	src      Source
+	prefix   string
+	registry *Registry
+
+	fileSuffix  string
+	fileMaxSize int64
+	fileFirst   bool
+
+	emptyAsUnset bool
+	observers    []Observer
+	// secrets holds the keys marked secret, shared with the copies of the Env.
+	secrets *sync.Map
+}
+
+// Option configures an Env.
+type Option func(e *Env)
+
+// New returns an Env reading variables from 'src' and configured by 'opts'.
+func New(src Source, opts ...Option) *Env {
+	e := &Env{src: src, fileMaxSize: defaultFileMaxSize, secrets: new(sync.Map)}
+	for _, opt := range opts {
+		opt(e)
 	}
 
-	return value
+	return e
 }
 
-// GetIn returns the environment variable set in 'key'.
-// If value is not set for 'key' or different from 'in', it returns 'defaultValue'.
-// 'in' is case sensitive.
-func GetIn(key, defaultValue string, in ...string) string {
-	value := Get(key, defaultValue)
-	if value == defaultValue {
-		return defaultValue
+// With returns a copy of 'e' configured by 'opts'.
+func (e *Env) With(opts ...Option) *Env {
+	c := *e
+	for _, opt := range opts {
+		opt(&c)
 	}
 
-	for _, v := range in {
-		if value == v {
-			return value
+	return &c
+}
+
+// With returns a copy of the default Env configured by 'opts'.
+func With(opts ...Option) *Env {
+	return std.With(opts...)
+}
+
+var std = New(OS, WithRegistry(DefaultRegistry))
+
+// Default returns the Env used by the package-level functions.
+func Default() *Env {
+	return std
+}
+
+// SetDefault replaces the Env used by the package-level functions with 'e' and returns the previous one.
+// It is meant for tests and must not be called while package-level functions are running.
+func SetDefault(e *Env) *Env {
+	old := std
+	std = e
+	return old
+}
+
+// Source returns the source of 'e'.
+func (e *Env) Source() Source {
+	return e.src
+}
+
+// Keys returns every key set in the source of 'e' and starting with its prefix,
+// with the prefix removed, or nil if the source does not implement Keyer.
+func (e *Env) Keys() []string {
+	k, ok := e.src.(Keyer)
+	if !ok {
+		return nil
+	}
+
+	if e.prefix == "" {
+		return k.Keys()
+	}
+
+	var keys []string
+	for _, key := range k.Keys() {
+		if strings.HasPrefix(key, e.prefix) && len(key) > len(e.prefix) {
+			keys = append(keys, key[len(e.prefix):])
 		}
 	}
 
-	return defaultValue
+	return keys
+}
+
+// WithPrefix returns a view of 'e' where every key is prefixed with 'prefix'.
+// Views nest: e.WithPrefix("A_").WithPrefix("B_") reads "A_B_KEY" for "KEY".
+// Errors and panics report the fully-qualified key.
+func (e *Env) WithPrefix(prefix string) *Env {
+	c := *e
+	c.prefix += prefix
+
+	return &c
+}
+
+// WithPrefix returns a view of the environment where every key is prefixed with 'prefix'.
+func WithPrefix(prefix string) *Env {
+	return std.WithPrefix(prefix)
+}
+
+// Prefix returns the prefix of 'e'.
+func (e *Env) Prefix() string {
+	return e.prefix
+}
+
+// key returns the fully-qualified form of 'key'.
+func (e *Env) key(key string) string {
+	return e.prefix + key
+}
+
+// Get is like the package-level Get but reads from the source of 'e'.
+func (e *Env) Get(key, defaultValue string) string {
+	return e.Var(key).Default(defaultValue).String()
+}
+
+// GetIn is like the package-level GetIn but reads from the source of 'e'.
+func (e *Env) GetIn(key, defaultValue string, in ...string) string {
+	return e.Var(key).Default(defaultValue).OneOf(in...).String()
+}
+
+// GetInCaseInsensitive is like the package-level GetInCaseInsensitive but reads from the source of 'e'.
+func (e *Env) GetInCaseInsensitive(key, defaultValue string, in ...string) string {
+	return e.Var(key).Default(defaultValue).OneOf(in...).CaseInsensitive().String()
+}
+
+// GetInRegex is like the package-level GetInRegex but reads from the source of 'e'.
+func (e *Env) GetInRegex(key, defaultValue string, regex ...string) string {
+	return e.Var(key).Default(defaultValue).Match(regex...).String()
+}
+
+// GetExcept is like the package-level GetExcept but reads from the source of 'e'.
+func (e *Env) GetExcept(key, defaultValue string, except ...string) string {
+	return e.Var(key).Default(defaultValue).NotOneOf(except...).String()
+}
+
+// GetExceptCaseInsensitive is like the package-level GetExceptCaseInsensitive but reads from the source of 'e'.
+func (e *Env) GetExceptCaseInsensitive(key, defaultValue string, except ...string) string {
+	return e.Var(key).Default(defaultValue).NotOneOf(except...).CaseInsensitive().String()
+}
+
+// GetExceptRegex is like the package-level GetExceptRegex but reads from the source of 'e'.
+func (e *Env) GetExceptRegex(key, defaultValue string, regex ...string) string {
+	return e.Var(key).Default(defaultValue).NotMatch(regex...).String()
+}
+
+// Lookup is like the package-level Lookup but reads from the source of 'e'.
+func (e *Env) Lookup(key string) (string, error) {
+	return e.Var(key).Lookup()
+}
+
+// LookupIn is like the package-level LookupIn but reads from the source of 'e'.
+func (e *Env) LookupIn(key string, in ...string) (string, error) {
+	return e.Var(key).OneOf(in...).Lookup()
+}
+
+// LookupInCaseInsensitive is like the package-level LookupInCaseInsensitive but reads from the source of 'e'.
+func (e *Env) LookupInCaseInsensitive(key string, in ...string) (string, error) {
+	return e.Var(key).OneOf(in...).CaseInsensitive().Lookup()
+}
+
+// LookupInRegex is like the package-level LookupInRegex but reads from the source of 'e'.
+func (e *Env) LookupInRegex(key string, regex ...string) (string, error) {
+	return e.Var(key).Match(regex...).Lookup()
+}
+
+// LookupExcept is like the package-level LookupExcept but reads from the source of 'e'.
+func (e *Env) LookupExcept(key string, except ...string) (string, error) {
+	return e.Var(key).NotOneOf(except...).Lookup()
+}
+
+// LookupExceptCaseInsensitive is like the package-level LookupExceptCaseInsensitive but reads from the source of 'e'.
+func (e *Env) LookupExceptCaseInsensitive(key string, except ...string) (string, error) {
+	return e.Var(key).NotOneOf(except...).CaseInsensitive().Lookup()
+}
+
+// LookupExceptRegex is like the package-level LookupExceptRegex but reads from the source of 'e'.
+func (e *Env) LookupExceptRegex(key string, regex ...string) (string, error) {
+	return e.Var(key).NotMatch(regex...).Lookup()
+}
+
+// MustGet is like the package-level MustGet but reads from the source of 'e'.
+func (e *Env) MustGet(key string) string {
+	return must(e.Lookup(key))
+}
+
+// MustGetIn is like the package-level MustGetIn but reads from the source of 'e'.
+func (e *Env) MustGetIn(key string, in ...string) string {
+	return must(e.LookupIn(key, in...))
+}
+
+// MustGetInCaseInsensitive is like the package-level MustGetInCaseInsensitive but reads from the source of 'e'.
+func (e *Env) MustGetInCaseInsensitive(key string, in ...string) string {
+	return must(e.LookupInCaseInsensitive(key, in...))
+}
+
+// MustGetInRegex is like the package-level MustGetInRegex but reads from the source of 'e'.
+func (e *Env) MustGetInRegex(key string, regex ...string) string {
+	return must(e.LookupInRegex(key, regex...))
+}
+
+// MustGetExcept is like the package-level MustGetExcept but reads from the source of 'e'.
+func (e *Env) MustGetExcept(key string, except ...string) string {
+	return must(e.LookupExcept(key, except...))
+}
+
+// MustGetExceptCaseInsensitive is like the package-level MustGetExceptCaseInsensitive but reads from the source of 'e'.
+func (e *Env) MustGetExceptCaseInsensitive(key string, except ...string) string {
+	return must(e.LookupExceptCaseInsensitive(key, except...))
+}
+
+// MustGetExceptRegex is like the package-level MustGetExceptRegex but reads from the source of 'e'.
+func (e *Env) MustGetExceptRegex(key string, regex ...string) string {
+	return must(e.LookupExceptRegex(key, regex...))
+}
+
+// Get returns the environment variable set in 'key'.
+// If value is not set for 'key', it returns 'defaultValue'.
+func Get(key, defaultValue string) string {
+	return std.Get(key, defaultValue)
+}
+
+// GetIn returns the environment variable set in 'key'.
+// If value is not set for 'key' or different from 'in', it returns 'defaultValue'.
+// 'in' is case sensitive.
+func GetIn(key, defaultValue string, in ...string) string {
+	return std.GetIn(key, defaultValue, in...)
 }
 
 // GetInCaseInsensitive returns the environment variable set to 'key'.
 // If value is not set for 'key' or different from 'in', it returns 'defaultValue'.
 // 'in' is not case sensitive.
 func GetInCaseInsensitive(key, defaultValue string, in ...string) string {
-	value := Get(key, defaultValue)
-	if value == defaultValue {
-		return defaultValue
-	}
-
-	for _, v := range in {
-		if strings.ToLower(value) == strings.ToLower(v) {
-			return value
-		}
-	}
-
-	return defaultValue
+	return std.GetInCaseInsensitive(key, defaultValue, in...)
 }
 
 // GetInRegex returns the environment variable set to 'key'.
 // If value is not set for 'key' or does not match the regular expression 'regex', it returns 'defaultValue'.
 func GetInRegex(key, defaultValue string, regex ...string) string {
-	value := Get(key, defaultValue)
-	if value == defaultValue {
-		return defaultValue
-	}
-
-	for _, r := range regex {
-		re, err := regexp.Compile(r)
-		if err != nil {
-			return defaultValue
-		}
-
-		if re.MatchString(value) {
-			return value
-		}
-	}
-
-	return defaultValue
+	return std.GetInRegex(key, defaultValue, regex...)
 }
 
 // GetExcept returns the environment variable set to 'key'.
 // If value is not set for 'key' or equal to 'except', it returns 'defaultValue'.
 // 'except' is case sensitive.
 func GetExcept(key, defaultValue string, except ...string) string {
-	value := Get(key, defaultValue)
-	if value == defaultValue {
-		return defaultValue
-	}
-
-	for _, v := range except {
-		if value == v {
-			return defaultValue
-		}
-	}
-
-	return value
+	return std.GetExcept(key, defaultValue, except...)
 }
 
 // GetExceptCaseInsensitive returns the environment variable set to 'key'.
 // If value is not set for 'key' or equal to 'except', it returns 'defaultValue'.
 // 'except' is not case sensitive.
 func GetExceptCaseInsensitive(key, defaultValue string, except ...string) string {
-	value := Get(key, defaultValue)
-	if value == defaultValue {
-		return defaultValue
-	}
-
-	for _, v := range except {
-		if strings.ToLower(value) == strings.ToLower(v) {
-			return defaultValue
-		}
-	}
-
-	return value
+	return std.GetExceptCaseInsensitive(key, defaultValue, except...)
 }
 
 // GetExceptRegex returns the environment variable set to 'key'.
 // If value is not set for 'key' or matches the regular expression 'regex', it returns 'defaultValue'.
 func GetExceptRegex(key, defaultValue string, regex ...string) string {
-	value := Get(key, defaultValue)
-	if value == defaultValue {
-		return defaultValue
-	}
+	return std.GetExceptRegex(key, defaultValue, regex...)
+}
 
-	for _, r := range regex {
-		re, err := regexp.Compile(r)
-		if err != nil {
-			return defaultValue
-		}
+// Lookup returns the environment variable set to 'key'.
+// If value is not set for 'key', it returns an *Error wrapping ErrNotSet.
+func Lookup(key string) (string, error) {
+	return std.Lookup(key)
+}
 
-		if re.MatchString(value) {
-			return defaultValue
-		}
-	}
+// LookupIn returns the environment variable set to 'key'.
+// If value is not set for 'key' or different from 'in', it returns an *Error.
+// 'in' is case sensitive.
+func LookupIn(key string, in ...string) (string, error) {
+	return std.LookupIn(key, in...)
+}
 
-	return value
+// LookupInCaseInsensitive returns the environment variable set to 'key'.
+// If value is not set for 'key' or different from 'in', it returns an *Error.
+// 'in' is not case sensitive.
+func LookupInCaseInsensitive(key string, in ...string) (string, error) {
+	return std.LookupInCaseInsensitive(key, in...)
+}
+
+// LookupInRegex returns the environment variable set to 'key'.
+// If value is not set for 'key' or does not match the regular expression 'regex', it returns an *Error.
+func LookupInRegex(key string, regex ...string) (string, error) {
+	return std.LookupInRegex(key, regex...)
+}
+
+// LookupExcept returns the environment variable set to 'key'.
+// If value is not set for 'key' or equal to 'except', it returns an *Error.
+// 'except' is case sensitive.
+func LookupExcept(key string, except ...string) (string, error) {
+	return std.LookupExcept(key, except...)
+}
+
+// LookupExceptCaseInsensitive returns the environment variable set to 'key'.
+// If value is not set for 'key' or equal to 'except', it returns an *Error.
+// 'except' is not case sensitive.
+func LookupExceptCaseInsensitive(key string, except ...string) (string, error) {
+	return std.LookupExceptCaseInsensitive(key, except...)
+}
+
+// LookupExceptRegex returns the environment variable set to 'key'.
+// If value is not set for 'key' or matches the regular expression 'regex', it returns an *Error.
+func LookupExceptRegex(key string, regex ...string) (string, error) {
+	return std.LookupExceptRegex(key, regex...)
 }
 
 // MustGet returns the environment variable set to 'key'.
 // If value is not set for 'key', it raises a panic.
 func MustGet(key string) string {
-	value, ok := os.LookupEnv(key)
-	if !ok {
-		panic("env: can not find key: " + key)
-	}
-
-	return value
+	return std.MustGet(key)
 }
 
 // MustGetIn returns the environment variable set in 'key'.
 // If value is not set for 'key' or different from 'in', it raises a panic.
 // 'in' is case sensitive.
 func MustGetIn(key string, in ...string) string {
-	value := MustGet(key)
-
-	for _, v := range in {
-		if value == v {
-			return value
-		}
-	}
-
-	panic("env: value is not in: " + key)
+	return std.MustGetIn(key, in...)
 }
 
 // MustGetInCaseInsensitive returns the environment variable set to 'key'.
 // If value is not set for 'key' or different from 'in', it raises a panic.
 // 'in' is not case sensitive.
 func MustGetInCaseInsensitive(key string, in ...string) string {
-	value := MustGet(key)
-
-	for _, v := range in {
-		if strings.ToLower(value) == strings.ToLower(v) {
-			return value
-		}
-	}
-
-	panic("env: value is not in: " + key)
+	return std.MustGetInCaseInsensitive(key, in...)
 }
 
 // MustGetInRegex returns the environment variable set to 'key'.
 // If value is not set for 'key' or does not match the regular expression 'regex', it raises a panic.
 func MustGetInRegex(key string, regex ...string) string {
-	value := MustGet(key)
-
-	for _, r := range regex {
-		re, err := regexp.Compile(r)
-		if err != nil {
-			panic("env: failed to compile regex: " + key)
-		}
-
-		if re.MatchString(value) {
-			return value
-		}
-	}
-
-	panic("env: value is not in: " + key)
+	return std.MustGetInRegex(key, regex...)
 }
 
 // MustGetExcept returns the environment variable set to 'key'.
 // If value is not set for 'key' or equal to 'except', it raises a panic.
 // 'except' is case sensitive.
 func MustGetExcept(key string, except ...string) string {
-	value := MustGet(key)
-
-	for _, v := range except {
-		if value == v {
-			panic("env: value is not except: " + key)
-		}
-	}
-
-	return value
+	return std.MustGetExcept(key, except...)
 }
 
 // MustGetExceptCaseInsensitive returns the environment variable set to 'key'.
 // If value is not set for 'key' or equal to 'except', it raises a panic.
 // 'except' is not case sensitive.
 func MustGetExceptCaseInsensitive(key string, except ...string) string {
-	value := MustGet(key)
+	return std.MustGetExceptCaseInsensitive(key, except...)
+}
+
+// MustGetExceptRegex returns the environment variable set to 'key'.
+// If value is not set for 'key' or matches the regular expression 'regex', it raises a panic.
+func MustGetExceptRegex(key string, regex ...string) string {
+	return std.MustGetExceptRegex(key, regex...)
+}
+
+// must returns 'value' or raises a panic with 'err' if it is not nil.
+func must[T any](value T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+
+	return value
+}
+
+// equal reports whether 'a' and 'b' are equal, ignoring case if 'fold' is set.
+func equal(a, b string, fold bool) bool {
+	if fold {
+		return strings.ToLower(a) == strings.ToLower(b)
+	}
 
+	return a == b
+}
+
+// constraint describes a list of values or patterns for error messages.
+func constraint(kind string, fold bool, list []string) string {
+	if fold {
+		kind += "-case-insensitive"
+	}
+
+	return fmt.Sprintf("%s %q", kind, list)
+}
+
+// checkIn returns an error unless 'value' is equal to one of 'in'.
+func checkIn(key, value string, in []string, fold bool) error {
+	for _, v := range in {
+		if equal(value, v, fold) {
+			return nil
+		}
+	}
+
+	return &Error{Key: key, Value: value, Constraint: constraint("in", fold, in), Suggestion: suggest(value, in, fold), Err: ErrNotAllowed}
+}
+
+// checkExcept returns an error if 'value' is equal to one of 'except'.
+func checkExcept(key, value string, except []string, fold bool) error {
 	for _, v := range except {
-		if strings.ToLower(value) == strings.ToLower(v) {
-			panic("env: value is not except: " + key)
+		if equal(value, v, fold) {
+			return &Error{Key: key, Value: value, Constraint: constraint("except", fold, except), Err: ErrExcluded}
 		}
 	}
 
-	return value
+	return nil
 }
 
-// MustGetExceptRegex returns the environment variable set to 'key'.
-// If value is not set for 'key' or matches the regular expression 'regex', it raises a panic.
-func MustGetExceptRegex(key string, regex ...string) string {
-	value := MustGet(key)
+// checkInRegex returns an error unless 'value' matches one of the regular expressions 'regex'.
+func checkInRegex(key, value string, regex []string) error {
+	for _, r := range regex {
+		re, err := regexCache.compile(r)
+		if err != nil {
+			return &Error{Key: key, Value: value, Constraint: constraint("regex", false, []string{r}), Err: ErrBadPattern}
+		}
 
+		if re.MatchString(value) {
+			return nil
+		}
+	}
+
+	return &Error{Key: key, Value: value, Constraint: constraint("regex", false, regex), Err: ErrNotAllowed}
+}
+
+// checkExceptRegex returns an error if 'value' matches one of the regular expressions 'regex'.
+func checkExceptRegex(key, value string, regex []string) error {
 	for _, r := range regex {
-		re, err := regexp.Compile(r)
+		re, err := regexCache.compile(r)
 		if err != nil {
-			panic("env: failed to compile regex: " + key)
+			return &Error{Key: key, Value: value, Constraint: constraint("except-regex", false, []string{r}), Err: ErrBadPattern}
 		}
 
 		if re.MatchString(value) {
-			panic("env: value is not except: " + key)
+			return &Error{Key: key, Value: value, Constraint: constraint("except-regex", false, regex), Err: ErrExcluded}
 		}
 	}
 
-	return value
+	return nil
 }
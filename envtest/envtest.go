@@ -0,0 +1,169 @@
+// Package envtest provides environment fixtures for tests.
+//
+// Setenv, Unsetenv, Load and Isolate change the process environment and restore
+// it when the test ends, so they must not be used in parallel tests.
+// Tests that need to run in parallel should read variables from a Fake through
+// an env.Env instead.
+package envtest
+
+import (
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gomodrepo/env"
+)
+
+// Setenv sets the environment variable 'key' to 'value' for the duration of 't'.
+func Setenv(t testing.TB, key, value string) {
+	t.Helper()
+	restore(t, key)
+
+	if err := os.Setenv(key, value); err != nil {
+		t.Fatalf("envtest: %v", err)
+	}
+}
+
+// Unsetenv unsets the environment variable 'key' for the duration of 't'.
+func Unsetenv(t testing.TB, key string) {
+	t.Helper()
+	restore(t, key)
+
+	if err := os.Unsetenv(key); err != nil {
+		t.Fatalf("envtest: %v", err)
+	}
+}
+
+// Load sets the variables of the dotenv file 'name' for the duration of 't',
+// overriding the variables already set.
+func Load(t testing.TB, name string) {
+	t.Helper()
+
+	m, err := env.ParseFile(name)
+	if err != nil {
+		t.Fatalf("envtest: %v", err)
+	}
+
+	for _, k := range m.Keys() {
+		Setenv(t, k, m[k])
+	}
+}
+
+// restore restores the current value of 'key' when 't' ends.
+func restore(t testing.TB, key string) {
+	if value, ok := os.LookupEnv(key); ok {
+		t.Cleanup(func() { os.Setenv(key, value) })
+		return
+	}
+
+	t.Cleanup(func() { os.Unsetenv(key) })
+}
+
+// Snapshot is a copy of the process environment.
+type Snapshot []string
+
+// Take returns a Snapshot of the process environment.
+func Take() Snapshot {
+	return Snapshot(os.Environ())
+}
+
+// Restore makes the process environment identical to 's',
+// unsetting the variables set after it was taken.
+func (s Snapshot) Restore() {
+	os.Clearenv()
+
+	for _, kv := range s {
+		if k, v, ok := strings.Cut(kv, "="); ok {
+			os.Setenv(k, v)
+		}
+	}
+}
+
+// Isolate takes a Snapshot of the process environment and restores it when 't' ends.
+// If 'empty' is set, the process environment is emptied for the duration of 't'.
+func Isolate(t testing.TB, empty bool) {
+	t.Helper()
+
+	s := Take()
+	t.Cleanup(s.Restore)
+
+	if empty {
+		os.Clearenv()
+	}
+}
+
+// Fake is an in-memory env.Source.
+// A Fake is safe for concurrent use.
+type Fake struct {
+	mu   sync.RWMutex
+	vars map[string]string
+}
+
+// NewFake returns a Fake holding a copy of 'vars'.
+func NewFake(vars map[string]string) *Fake {
+	f := &Fake{vars: make(map[string]string, len(vars))}
+	for k, v := range vars {
+		f.vars[k] = v
+	}
+
+	return f
+}
+
+// Lookup returns the value of 'key' and whether it is set.
+func (f *Fake) Lookup(key string) (string, bool) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	value, ok := f.vars[key]
+	return value, ok
+}
+
+// Keys returns the keys set in the Fake in sorted order.
+func (f *Fake) Keys() []string {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	keys := make([]string, 0, len(f.vars))
+	for k := range f.vars {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
+
+// Set sets 'key' to 'value'.
+func (f *Fake) Set(key, value string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.vars[key] = value
+}
+
+// Unset unsets 'key'.
+func (f *Fake) Unset(key string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.vars, key)
+}
+
+// Env returns an Env reading from 'f' and configured by 'opts'.
+func (f *Fake) Env(opts ...env.Option) *env.Env {
+	return env.New(f, opts...)
+}
+
+// Use makes the package-level functions of env read from 'src' for the duration of 't'.
+// The variables are recorded in a fresh registry rather than env.DefaultRegistry.
+// Use changes global state, so it must not be used in parallel tests.
+func Use(t testing.TB, src env.Source, opts ...env.Option) *env.Env {
+	t.Helper()
+
+	e := env.New(src, append([]env.Option{env.WithRegistry(env.NewRegistry())}, opts...)...)
+	old := env.SetDefault(e)
+	t.Cleanup(func() { env.SetDefault(old) })
+
+	return e
+}
@@ -0,0 +1,139 @@
+package envtest_test
+
+import (
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/gomodrepo/env"
+	"github.com/gomodrepo/env/envtest"
+)
+
+const _testKey = "ENVTEST_KEY"
+
+func TestSetenv(t *testing.T) {
+	os.Setenv(_testKey, "before")
+	defer os.Unsetenv(_testKey)
+
+	t.Run("Setenv", func(t *testing.T) {
+		envtest.Setenv(t, _testKey, "during")
+		if got := env.Get(_testKey, ""); got != "during" {
+			t.Errorf("got '%v' want '%v'", got, "during")
+		}
+	})
+
+	if got := os.Getenv(_testKey); got != "before" {
+		t.Errorf("got '%v' want '%v'", got, "before")
+	}
+
+	t.Run("Unsetenv", func(t *testing.T) {
+		envtest.Unsetenv(t, _testKey)
+		if _, ok := os.LookupEnv(_testKey); ok {
+			t.Errorf("got '%v' set", _testKey)
+		}
+	})
+
+	if got := os.Getenv(_testKey); got != "before" {
+		t.Errorf("got '%v' want '%v'", got, "before")
+	}
+
+	os.Unsetenv(_testKey)
+	t.Run("SetenvUnset", func(t *testing.T) {
+		envtest.Setenv(t, _testKey, "during")
+	})
+
+	if _, ok := os.LookupEnv(_testKey); ok {
+		t.Errorf("got '%v' set", _testKey)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("Load", func(t *testing.T) {
+		envtest.Load(t, "testdata/fixture.env")
+		if got := env.Get("ENVTEST_PORT", ""); got != "5432" {
+			t.Errorf("got '%v' want '%v'", got, "5432")
+		}
+	})
+
+	if _, ok := os.LookupEnv("ENVTEST_HOST"); ok {
+		t.Errorf("got '%v' set", "ENVTEST_HOST")
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	os.Setenv(_testKey, "before")
+	defer os.Unsetenv(_testKey)
+
+	s := envtest.Take()
+	os.Setenv(_testKey, "after")
+	os.Setenv("ENVTEST_OTHER", "after")
+	s.Restore()
+
+	if got := os.Getenv(_testKey); got != "before" {
+		t.Errorf("got '%v' want '%v'", got, "before")
+	}
+
+	if _, ok := os.LookupEnv("ENVTEST_OTHER"); ok {
+		t.Errorf("got '%v' set", "ENVTEST_OTHER")
+	}
+
+	t.Run("Isolate", func(t *testing.T) {
+		envtest.Isolate(t, true)
+		if len(os.Environ()) != 0 {
+			t.Errorf("got environment '%v' want empty", os.Environ())
+		}
+	})
+
+	if got := os.Getenv(_testKey); got != "before" {
+		t.Errorf("got '%v' want '%v'", got, "before")
+	}
+}
+
+func TestFake(t *testing.T) {
+	f := envtest.NewFake(map[string]string{"MODE": "dev"})
+
+	t.Run("Parallel", func(t *testing.T) {
+		for i := 0; i < 4; i++ {
+			t.Run("Subtest", func(t *testing.T) {
+				t.Parallel()
+
+				e := f.Env()
+				var wg sync.WaitGroup
+				for j := 0; j < 50; j++ {
+					wg.Add(2)
+					go func() {
+						defer wg.Done()
+						f.Set("COUNT", "1")
+					}()
+					go func() {
+						defer wg.Done()
+						if got := e.GetIn("MODE", "prod", "dev", "prod"); got != "dev" {
+							t.Errorf("got '%v' want '%v'", got, "dev")
+						}
+					}()
+				}
+				wg.Wait()
+			})
+		}
+	})
+
+	f.Unset("COUNT")
+	if got := f.Keys(); len(got) != 1 || got[0] != "MODE" {
+		t.Errorf("got '%v' want '%v'", got, []string{"MODE"})
+	}
+}
+
+func TestUse(t *testing.T) {
+	f := envtest.NewFake(map[string]string{_testKey: "fake"})
+
+	t.Run("Use", func(t *testing.T) {
+		envtest.Use(t, f)
+		if got := env.MustGet(_testKey); got != "fake" {
+			t.Errorf("got '%v' want '%v'", got, "fake")
+		}
+	})
+
+	if _, err := env.Lookup(_testKey); err == nil {
+		t.Errorf("got '%v' set after the test", _testKey)
+	}
+}
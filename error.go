@@ -0,0 +1,151 @@
+package env
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrNotSet is returned when a variable is not set.
+	ErrNotSet = errors.New("env: variable is not set")
+	// ErrNotAllowed is returned when a value is not one of the allowed values or patterns.
+	ErrNotAllowed = errors.New("env: value is not allowed")
+	// ErrExcluded is returned when a value is one of the excluded values or patterns.
+	ErrExcluded = errors.New("env: value is excluded")
+	// ErrBadPattern is returned when a regular expression or a glob pattern is malformed.
+	ErrBadPattern = errors.New("env: bad pattern")
+	// ErrInvalid is returned when a value can not be parsed into the requested type.
+	ErrInvalid = errors.New("env: invalid value")
+	// ErrUndefined is returned when an expansion references a variable that is not set.
+	ErrUndefined = errors.New("env: undefined variable")
+	// ErrCycle is returned when expanding a variable references the variable itself,
+	// or when Bind meets a struct type nested in itself.
+	ErrCycle = errors.New("env: reference cycle")
+	// ErrSyntax is returned when a value is malformed, e.g. holds an unterminated expansion or quote.
+	ErrSyntax = errors.New("env: malformed value")
+	// ErrFile is returned when the file named by a _FILE variable can not be read.
+	ErrFile = errors.New("env: can not read file")
+	// ErrUnknown is returned when a variable is set under the prefix of an Env but was never declared.
+	ErrUnknown = errors.New("env: unknown variable")
+	// ErrOutOfRange is returned when a number does not satisfy its bounds.
+	ErrOutOfRange = errors.New("env: value is out of range")
+	// ErrEmpty is returned when a value required to be non-empty is empty or holds only spaces.
+	ErrEmpty = errors.New("env: value is empty")
+)
+
+// Error describes why the value of a variable was rejected.
+// It wraps one of the Err* sentinels and can be tested with errors.Is.
+type Error struct {
+	// Key is the variable that was looked up.
+	Key string
+	// Value is the offending value, empty if the variable is not set.
+	Value string
+	// Constraint describes the rule the value failed, e.g. `in ["dev" "prod"]`.
+	Constraint string
+	// Suggestion is the closest allowed value, or the closest key set when the
+	// variable is not set, empty if nothing is close enough.
+	Suggestion string
+	// Err is one of the Err* sentinels.
+	Err error
+}
+
+func (e *Error) Error() string {
+	msg := "env: " + e.reason()
+	if e.Key != "" {
+		msg += ": " + e.Key
+	}
+
+	switch e.Err {
+	case ErrNotSet, ErrUnknown:
+		if e.Suggestion != "" {
+			msg += " (did you mean " + e.Suggestion + "?)"
+		}
+
+		return msg
+	case ErrUndefined, ErrCycle:
+		if e.Constraint != "" {
+			msg += " (" + e.Constraint + ")"
+		}
+
+		return msg
+	}
+
+	msg = fmt.Sprintf("%s: value %q, %s", msg, e.Value, e.Constraint)
+	if e.Suggestion != "" {
+		msg += fmt.Sprintf(", did you mean %q?", e.Suggestion)
+	}
+
+	return msg
+}
+
+func (e *Error) reason() string {
+	switch e.Err {
+	case ErrNotSet:
+		return "can not find key"
+	case ErrNotAllowed:
+		return "value is not in"
+	case ErrExcluded:
+		return "value is not except"
+	case ErrBadPattern:
+		if strings.HasPrefix(e.Constraint, "glob") || strings.HasPrefix(e.Constraint, "except-glob") {
+			return "failed to compile glob"
+		}
+
+		return "failed to compile regex"
+	case ErrInvalid:
+		return "can not parse value"
+	case ErrUndefined:
+		return "undefined variable"
+	case ErrCycle:
+		return "reference cycle"
+	case ErrSyntax:
+		return "malformed value"
+	case ErrFile:
+		return "can not read file"
+	case ErrEmpty:
+		return "value is empty"
+	case ErrUnknown:
+		return "unknown variable"
+	case ErrOutOfRange:
+		return "value is out of range"
+	}
+
+	return strings.TrimPrefix(e.Err.Error(), "env: ")
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// unwrapAll, isAny and asAny implement the Unwrap, Is and As methods of the errors
+// holding several errors. Is and As let errors.Is and errors.As reach them before
+// Go 1.20, which added Unwrap() []error.
+func unwrapAll[E error](errs []E) []error {
+	all := make([]error, len(errs))
+	for i, err := range errs {
+		all[i] = err
+	}
+
+	return all
+}
+
+func isAny[E error](errs []E, target error) bool {
+	for _, err := range errs {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func asAny[E error](errs []E, target any) bool {
+	for _, err := range errs {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,268 @@
+package env
+
+import "strings"
+
+// Expand replaces the references to variables in 's' with their values in the source of 'e'.
+//
+// The following forms are supported, where 'word' may itself hold references:
+//
+//	$VAR, ${VAR}     the value of VAR, an error if VAR is not set
+//	${VAR:-word}     word if VAR is not set or empty
+//	${VAR-word}      word if VAR is not set
+//	${VAR:?msg}      an error holding msg if VAR is not set or empty
+//	${VAR?msg}       an error holding msg if VAR is not set
+//	${VAR:+word}     word if VAR is set and not empty, otherwise the empty string
+//	${VAR+word}      word if VAR is set, otherwise the empty string
+//	$$               a literal '$'
+//
+// The values of referenced variables are expanded as well. References are
+// looked up by their full name, regardless of the prefix of 'e'. A '$' that
+// does not start one of the forms above is kept as is.
+//
+// Errors are *Error values wrapping ErrUndefined, ErrCycle or ErrSyntax.
+func (e *Env) Expand(s string) (string, error) {
+	x := &expander{lookup: e.src.Lookup}
+	return x.expand(s)
+}
+
+// GetExpand is like the package-level GetExpand but reads from the source of 'e'.
+func (e *Env) GetExpand(key, defaultValue string) string {
+	value, err := e.LookupExpand(key)
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
+// LookupExpand is like the package-level LookupExpand but reads from the source of 'e'.
+func (e *Env) LookupExpand(key string) (string, error) {
+	value, err := e.Lookup(key)
+	if err != nil {
+		return "", err
+	}
+
+	x := &expander{lookup: e.src.Lookup, stack: []string{e.key(key)}}
+	return x.expand(value)
+}
+
+// MustGetExpand is like the package-level MustGetExpand but reads from the source of 'e'.
+func (e *Env) MustGetExpand(key string) string {
+	return must(e.LookupExpand(key))
+}
+
+// Expand replaces the references to variables in 's' with their values in the environment.
+// See Env.Expand for the supported forms.
+func Expand(s string) (string, error) {
+	return std.Expand(s)
+}
+
+// GetExpand returns the environment variable set to 'key' with its references expanded.
+// If value is not set for 'key' or can not be expanded, it returns 'defaultValue'.
+func GetExpand(key, defaultValue string) string {
+	return std.GetExpand(key, defaultValue)
+}
+
+// LookupExpand returns the environment variable set to 'key' with its references expanded.
+// If value is not set for 'key' or can not be expanded, it returns an *Error.
+func LookupExpand(key string) (string, error) {
+	return std.LookupExpand(key)
+}
+
+// MustGetExpand returns the environment variable set to 'key' with its references expanded.
+// If value is not set for 'key' or can not be expanded, it raises a panic.
+func MustGetExpand(key string) string {
+	return std.MustGetExpand(key)
+}
+
+type expander struct {
+	lookup func(key string) (string, bool)
+	// stack holds the variables being expanded, to detect cycles.
+	stack []string
+}
+
+func (x *expander) key() string {
+	if len(x.stack) == 0 {
+		return ""
+	}
+
+	return x.stack[len(x.stack)-1]
+}
+
+func (x *expander) syntaxError(s, msg string) error {
+	return &Error{Key: x.key(), Value: s, Constraint: msg, Err: ErrSyntax}
+}
+
+func (x *expander) expand(s string) (string, error) {
+	var b strings.Builder
+
+	for i := 0; i < len(s); i++ {
+		if s[i] != '$' || i+1 == len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+
+		switch c := s[i+1]; {
+		case c == '$':
+			b.WriteByte('$')
+			i++
+		case c == '{':
+			end := closingBrace(s, i+2)
+			if end < 0 {
+				return "", x.syntaxError(s, "missing '}'")
+			}
+
+			value, err := x.braced(s[i+2 : end])
+			if err != nil {
+				return "", err
+			}
+
+			b.WriteString(value)
+			i = end
+		case isNameStart(c):
+			j := i + 2
+			for j < len(s) && isNameRune(s[j]) {
+				j++
+			}
+
+			value, err := x.ref(s[i+1 : j])
+			if err != nil {
+				return "", err
+			}
+
+			b.WriteString(value)
+			i = j - 1
+		default:
+			b.WriteByte('$')
+		}
+	}
+
+	return b.String(), nil
+}
+
+// braced expands the content of a ${...} expression.
+func (x *expander) braced(expr string) (string, error) {
+	n := 0
+	for n < len(expr) && isNameRune(expr[n]) && (n > 0 || isNameStart(expr[n])) {
+		n++
+	}
+
+	if n == 0 {
+		return "", x.syntaxError("${"+expr+"}", "missing variable name")
+	}
+
+	name, rest := expr[:n], expr[n:]
+	if rest == "" {
+		return x.ref(name)
+	}
+
+	colon := rest[0] == ':'
+	if colon {
+		rest = rest[1:]
+	}
+
+	if rest == "" || !strings.ContainsRune("-?+", rune(rest[0])) {
+		return "", x.syntaxError("${"+expr+"}", "unknown operator")
+	}
+
+	op, word := rest[0], rest[1:]
+
+	value, ok, err := x.resolve(name)
+	if err != nil {
+		return "", err
+	}
+
+	unset := !ok || (colon && value == "")
+
+	switch op {
+	case '-':
+		if unset {
+			return x.expand(word)
+		}
+
+		return value, nil
+	case '?':
+		if unset {
+			if word == "" {
+				word = "parameter null or not set"
+			}
+
+			return "", &Error{Key: name, Constraint: word, Err: ErrUndefined}
+		}
+
+		return value, nil
+	default:
+		if unset {
+			return "", nil
+		}
+
+		return x.expand(word)
+	}
+}
+
+// ref returns the expanded value of 'name', which must be set.
+func (x *expander) ref(name string) (string, error) {
+	value, ok, err := x.resolve(name)
+	if err != nil {
+		return "", err
+	}
+
+	if !ok {
+		return "", &Error{Key: name, Err: ErrUndefined}
+	}
+
+	return value, nil
+}
+
+// resolve returns the expanded value of 'name' and whether it is set.
+func (x *expander) resolve(name string) (string, bool, error) {
+	raw, ok := x.lookup(name)
+	if !ok {
+		return "", false, nil
+	}
+
+	for i, k := range x.stack {
+		if k == name {
+			chain := append(append([]string{}, x.stack[i:]...), name)
+			return "", false, &Error{Key: name, Constraint: strings.Join(chain, " -> "), Err: ErrCycle}
+		}
+	}
+
+	x.stack = append(x.stack, name)
+	value, err := x.expand(raw)
+	x.stack = x.stack[:len(x.stack)-1]
+
+	return value, true, err
+}
+
+// closingBrace returns the index of the '}' closing the expression starting at 'i',
+// taking nested ${...} expressions into account, or -1.
+func closingBrace(s string, i int) int {
+	depth := 0
+
+	for ; i < len(s); i++ {
+		switch {
+		case s[i] == '$' && i+1 < len(s) && s[i+1] == '$':
+			i++
+		case s[i] == '$' && i+1 < len(s) && s[i+1] == '{':
+			depth++
+			i++
+		case s[i] == '}':
+			if depth == 0 {
+				return i
+			}
+
+			depth--
+		}
+	}
+
+	return -1
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+func isNameRune(c byte) bool {
+	return isNameStart(c) || '0' <= c && c <= '9'
+}
@@ -0,0 +1,87 @@
+package env_test
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/gomodrepo/env"
+)
+
+func TestExpand(t *testing.T) {
+	e := env.New(env.Map{
+		"DB_USER": "app",
+		"DB_HOST": "db",
+		"EMPTY":   "",
+		"URL":     "postgres://${DB_USER}@$DB_HOST/app",
+		"CYCLE_A": "${CYCLE_B}",
+		"CYCLE_B": "$CYCLE_A",
+		"SELF":    "x${SELF}",
+	})
+
+	scenarios := []struct {
+		desc      string
+		in        string
+		wantValue string
+		wantErr   error
+	}{
+		{desc: "#00", in: "plain", wantValue: "plain"},
+		{desc: "#01", in: "${URL}", wantValue: "postgres://app@db/app"},
+		{desc: "#02", in: "$$DB_HOST costs 5$", wantValue: "$DB_HOST costs 5$"},
+		{desc: "#03", in: "${MISSING:-${DB_HOST}:5432}", wantValue: "db:5432"},
+		{desc: "#04", in: "${EMPTY:-default}", wantValue: "default"},
+		{desc: "#05", in: "${EMPTY-default}", wantValue: ""},
+		{desc: "#06", in: "${MISSING-default}", wantValue: "default"},
+		{desc: "#07", in: "${DB_HOST:+set}${EMPTY:+set}${EMPTY+set}", wantValue: "setset"},
+		{desc: "#08", in: "${MISSING+set}", wantValue: ""},
+		{desc: "#09", in: "${DB_HOST:?required}", wantValue: "db"},
+		{desc: "#10", in: "${EMPTY:?required}", wantErr: env.ErrUndefined},
+		{desc: "#11", in: "${EMPTY?required}", wantValue: ""},
+		{desc: "#12", in: "$MISSING", wantErr: env.ErrUndefined},
+		{desc: "#13", in: "${CYCLE_A}", wantErr: env.ErrCycle},
+		{desc: "#14", in: "${SELF:-x}", wantErr: env.ErrCycle},
+		{desc: "#15", in: "${DB_HOST", wantErr: env.ErrSyntax},
+		{desc: "#16", in: "${1A}", wantErr: env.ErrSyntax},
+		{desc: "#17", in: "${DB_HOST:=x}", wantErr: env.ErrSyntax},
+		{desc: "#18", in: "$ 1 ${MISSING:-}", wantValue: "$ 1 "},
+	}
+
+	for _, s := range scenarios {
+		t.Run("Expand", func(t *testing.T) {
+			got, err := e.Expand(s.in)
+			if got != s.wantValue || !errors.Is(err, s.wantErr) {
+				t.Errorf("%v: got '%v' '%v' want '%v' '%v'", s.desc, got, err, s.wantValue, s.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetExpand(t *testing.T) {
+	os.Setenv(_testKey, "${TEST_KEY_HOST}:${TEST_KEY_PORT:-80}")
+	os.Setenv("TEST_KEY_HOST", "localhost")
+	defer os.Unsetenv(_testKey)
+	defer os.Unsetenv("TEST_KEY_HOST")
+
+	if got := env.GetExpand(_testKey, _defaultValue); got != "localhost:80" {
+		t.Errorf("got '%v' want 'localhost:80'", got)
+	}
+
+	os.Setenv(_testKey, "${TEST_KEY}")
+
+	if got := env.GetExpand(_testKey, _defaultValue); got != _defaultValue {
+		t.Errorf("got '%v' want '%v'", got, _defaultValue)
+	}
+
+	_, err := env.LookupExpand(_testKey)
+	if err == nil || err.Error() != "env: reference cycle: TEST_KEY (TEST_KEY -> TEST_KEY)" {
+		t.Errorf("got error '%v'", err)
+	}
+
+	defer func() {
+		if p := recover(); p == nil {
+			t.Errorf("gotPanic '%v' wantPanic 'true'", p)
+		}
+	}()
+
+	env.MustGetExpand(_testKey)
+}
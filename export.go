@@ -0,0 +1,218 @@
+package env
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Format is a serialization format of variables, used by Export.
+type Format int
+
+const (
+	// Dotenv writes KEY=value lines readable by Parse, quoting values when needed.
+	Dotenv Format = iota
+	// JSON writes a JSON object mapping keys to values.
+	JSON
+	// Shell writes POSIX "export KEY='value'" statements.
+	Shell
+	// Docker writes "-e 'KEY=value'" arguments of docker run, one per line.
+	Docker
+	// Systemd writes Environment= lines of a systemd unit.
+	Systemd
+)
+
+var formatNames = []string{"dotenv", "json", "shell", "docker", "systemd"}
+
+func (f Format) String() string {
+	if f < 0 || int(f) >= len(formatNames) {
+		return fmt.Sprintf("Format(%d)", int(f))
+	}
+
+	return formatNames[f]
+}
+
+// ParseFormat returns the Format named 'name': dotenv, json, shell, docker or systemd.
+func ParseFormat(name string) (Format, error) {
+	for i, n := range formatNames {
+		if n == name {
+			return Format(i), nil
+		}
+	}
+
+	return 0, fmt.Errorf("env: unknown format %q", name)
+}
+
+// Collect returns the values of 'keys' in the source of 'e', qualified with its prefix,
+// including the files of WithFiles. Keys that are not set are omitted.
+//
+// If no key is given, it returns the effective value of every variable declared in
+// the registry of 'e', including the default values of the variables that are not set.
+// Secret values are included, so the result must be handled with care.
+func (e *Env) Collect(keys ...string) (Map, error) {
+	m := make(Map)
+
+	if len(keys) > 0 {
+		for _, k := range keys {
+			key := e.key(k)
+			value, origin, err := e.lookup(key, e.emptyAsUnset)
+			if err != nil {
+				return nil, err
+			}
+
+			if origin != "" {
+				m[key] = value
+			}
+		}
+
+		return m, nil
+	}
+
+	if e.registry == nil {
+		return m, nil
+	}
+
+	for _, d := range e.registry.Decls() {
+		value, origin, err := e.lookup(d.Key, e.emptyAsUnset)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case origin != "":
+			m[d.Key] = value
+		case d.HasDefault:
+			m[d.Key] = d.Default
+		}
+	}
+
+	return m, nil
+}
+
+// Collect returns the values of 'keys' in the environment, or of every variable
+// read through the package if no key is given. See Env.Collect.
+func Collect(keys ...string) (Map, error) {
+	return std.Collect(keys...)
+}
+
+// CanExport reports whether 'key' can be written in the format 'f'.
+// Shell requires letters, digits and underscores, not starting with a digit; Dotenv
+// also allows dots and dashes, as Parse does; Docker and Systemd reject '=' and
+// line breaks; JSON accepts any key.
+func (f Format) CanExport(key string) bool {
+	switch f {
+	case Shell:
+		return isName(key)
+	case Dotenv:
+		return isDotenvKey(key)
+	case Docker, Systemd:
+		return key != "" && !strings.ContainsAny(key, "=\n\r")
+	}
+
+	return true
+}
+
+// Export writes the variables of 'm' to 'w' in the format 'f', sorted by key.
+// Every key must satisfy f.CanExport.
+func Export(w io.Writer, f Format, m Map) error {
+	keys := m.Keys()
+	for _, k := range keys {
+		if !f.CanExport(k) {
+			return fmt.Errorf("env: can not export %q: invalid %v key", k, f)
+		}
+	}
+
+	if f == JSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(map[string]string(m))
+	}
+
+	var b strings.Builder
+	for _, k := range keys {
+		v := m[k]
+
+		switch f {
+		case Dotenv:
+			b.WriteString(k + "=" + dotenvQuote(v) + "\n")
+		case Shell:
+			b.WriteString("export " + k + "=" + shellQuote(v) + "\n")
+		case Docker:
+			b.WriteString("-e " + shellQuote(k+"="+v) + "\n")
+		case Systemd:
+			b.WriteString("Environment=" + systemdQuote(k+"="+v) + "\n")
+		default:
+			return fmt.Errorf("env: unknown format %v", f)
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func isName(s string) bool {
+	if s == "" || !isNameStart(s[0]) {
+		return false
+	}
+
+	for i := 1; i < len(s); i++ {
+		if !isNameRune(s[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isDotenvKey(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for i, r := range s {
+		if !isKeyRune(r, i == 0) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// dotenvQuote returns 'v' unquoted if it is made of safe characters, and double quoted otherwise.
+func dotenvQuote(v string) string {
+	safe := true
+	for i := 0; i < len(v) && safe; i++ {
+		safe = isNameRune(v[i]) || strings.IndexByte("-_./:,@+%", v[i]) >= 0
+	}
+
+	if safe {
+		return v
+	}
+
+	return `"` + strings.NewReplacer(
+		`\`, `\\`,
+		`"`, `\"`,
+		"$", `\$`,
+		"`", "\\`",
+		"\n", `\n`,
+		"\r", `\r`,
+		"\t", `\t`,
+	).Replace(v) + `"`
+}
+
+// shellQuote returns 'v' single quoted for a POSIX shell, each quote closing the string, escaped and reopened.
+func shellQuote(v string) string {
+	return "'" + strings.ReplaceAll(v, "'", `'\''`) + "'"
+}
+
+// systemdQuote returns 'v' double quoted for a systemd Environment= assignment,
+// with '%' escaped so that it is not taken as a specifier.
+func systemdQuote(v string) string {
+	return `"` + strings.NewReplacer(
+		`\`, `\\`,
+		`"`, `\"`,
+		"\n", `\n`,
+		"%", "%%",
+	).Replace(v) + `"`
+}
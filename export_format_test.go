@@ -0,0 +1,119 @@
+package env_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gomodrepo/env"
+)
+
+func TestExport(t *testing.T) {
+	m := env.Map{
+		"HOST":  "db.internal",
+		"QUOTE": `it's "quoted"`,
+		"MULTI": "a\nb $HOME 100%",
+	}
+
+	scenarios := []struct {
+		desc   string
+		format env.Format
+		want   string
+	}{
+		{
+			desc:   "#00",
+			format: env.Dotenv,
+			want:   "HOST=db.internal\nMULTI=\"a\\nb \\$HOME 100%\"\nQUOTE=\"it's \\\"quoted\\\"\"\n",
+		},
+		{
+			desc:   "#01",
+			format: env.Shell,
+			want:   "export HOST='db.internal'\nexport MULTI='a\nb $HOME 100%'\nexport QUOTE='it'\\''s \"quoted\"'\n",
+		},
+		{
+			desc:   "#02",
+			format: env.Docker,
+			want:   "-e 'HOST=db.internal'\n-e 'MULTI=a\nb $HOME 100%'\n-e 'QUOTE=it'\\''s \"quoted\"'\n",
+		},
+		{
+			desc:   "#03",
+			format: env.Systemd,
+			want:   "Environment=\"HOST=db.internal\"\nEnvironment=\"MULTI=a\\nb $HOME 100%%\"\nEnvironment=\"QUOTE=it's \\\"quoted\\\"\"\n",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run("Export", func(t *testing.T) {
+			var b strings.Builder
+			if err := env.Export(&b, s.format, m); err != nil {
+				t.Fatalf("%v: got '%v'", s.desc, err)
+			}
+
+			if b.String() != s.want {
+				t.Errorf("%v: got\n%v\nwant\n%v", s.desc, b.String(), s.want)
+			}
+		})
+	}
+
+	var b strings.Builder
+	env.Export(&b, env.Dotenv, m)
+
+	parsed, err := env.Parse(strings.NewReader(b.String()))
+	if err != nil || !reflect.DeepEqual(parsed, m) {
+		t.Errorf("got '%v', '%v' want '%v'", parsed, err, m)
+	}
+
+	b.Reset()
+	env.Export(&b, env.JSON, m)
+
+	var decoded map[string]string
+	if err := json.Unmarshal([]byte(b.String()), &decoded); err != nil || !reflect.DeepEqual(env.Map(decoded), m) {
+		t.Errorf("got '%v', '%v' want '%v'", decoded, err, m)
+	}
+
+	if err := env.Export(&b, env.Shell, env.Map{"1BAD": "x"}); err == nil {
+		t.Errorf("got nil want an error")
+	}
+
+	dotted := env.Map{"app.name": "x", "A-B": "y"}
+	for _, f := range []env.Format{env.Dotenv, env.JSON, env.Docker, env.Systemd} {
+		if err := env.Export(&b, f, dotted); err != nil {
+			t.Errorf("%v: got '%v' want nil", f, err)
+		}
+	}
+
+	b.Reset()
+	env.Export(&b, env.Dotenv, dotted)
+	if parsed, err := env.Parse(strings.NewReader(b.String())); err != nil || !reflect.DeepEqual(parsed, dotted) {
+		t.Errorf("got '%v', '%v' want '%v'", parsed, err, dotted)
+	}
+
+	if env.Shell.CanExport("app.name") || env.Dotenv.CanExport("A=B") || !env.JSON.CanExport("A=B") {
+		t.Errorf("got CanExport accepting invalid keys or rejecting valid ones")
+	}
+
+	if f, err := env.ParseFormat("systemd"); err != nil || f != env.Systemd {
+		t.Errorf("got '%v', '%v' want '%v'", f, err, env.Systemd)
+	}
+}
+
+func TestCollect(t *testing.T) {
+	e := env.New(env.Map{"APP_MODE": "prod", "APP_HOST": "db", "OTHER": "x"}, env.WithRegistry(env.NewRegistry())).WithPrefix("APP_")
+
+	e.Get("MODE", "dev")
+	e.GetInt("PORT", 8080)
+	e.Var("TOKEN").Declare()
+
+	got, err := e.Collect()
+	want := env.Map{"APP_MODE": "prod", "APP_PORT": "8080"}
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("got '%v', '%v' want '%v'", got, err, want)
+	}
+
+	got, err = e.Collect("HOST", "MISSING")
+	want = env.Map{"APP_HOST": "db"}
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("got '%v', '%v' want '%v'", got, err, want)
+	}
+}
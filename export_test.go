@@ -0,0 +1,16 @@
+package env
+
+import "sync/atomic"
+
+// Compilations returns the number of regular expressions compiled by the string-pattern functions.
+func Compilations() uint64 {
+	return atomic.LoadUint64(&compilations)
+}
+
+// RegexCacheLen returns the number of expressions held by the regular expression cache.
+func RegexCacheLen() int {
+	regexCache.mu.Lock()
+	defer regexCache.mu.Unlock()
+
+	return len(regexCache.ring)
+}
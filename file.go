@@ -0,0 +1,111 @@
+package env
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// defaultFileMaxSize is the largest file WithFiles reads unless WithFileMaxSize is used.
+const defaultFileMaxSize = 1 << 20
+
+// FilePrecedence decides which of KEY and KEY_FILE is used when both are set.
+type FilePrecedence int
+
+const (
+	// KeyFirst uses KEY when it is set and reads KEY_FILE otherwise.
+	KeyFirst FilePrecedence = iota
+	// FileFirst reads KEY_FILE when it is set and uses KEY otherwise.
+	FileFirst
+)
+
+// WithFiles resolves a variable that is not set from the file named by the
+// variable suffixed with 'suffix', following the Docker and Kubernetes secret
+// convention where DB_PASSWORD_FILE=/run/secrets/db provides DB_PASSWORD.
+// Trailing newlines are trimmed from the content of the file.
+//
+// When the file can not be read, the Get functions return their default value,
+// the Lookup functions return an *Error wrapping ErrFile and the MustGet
+// functions raise a panic with it.
+func WithFiles(suffix string) Option {
+	return func(e *Env) {
+		e.fileSuffix = suffix
+	}
+}
+
+// WithFileMaxSize sets the largest file WithFiles reads, 1 MiB by default.
+// Larger files are reported as ErrFile.
+func WithFileMaxSize(n int64) Option {
+	return func(e *Env) {
+		e.fileMaxSize = n
+	}
+}
+
+// WithFilePrecedence sets which of KEY and KEY_FILE is used when both are set, KeyFirst by default.
+func WithFilePrecedence(p FilePrecedence) Option {
+	return func(e *Env) {
+		e.fileFirst = p == FileFirst
+	}
+}
+
+// lookup returns the value of 'key' and its origin, OriginSource or OriginFile,
+// or an empty origin if it is not set. It reads the source of 'e' and, if
+// enabled, the file named by the _FILE variable. If 'empty' is set, blank values
+// and file names are not set, so that KEY= falls back to KEY_FILE.
+func (e *Env) lookup(key string, empty bool) (string, string, error) {
+	if e.fileSuffix == "" {
+		return e.lookupSource(key, empty)
+	}
+
+	if !e.fileFirst {
+		if value, origin, _ := e.lookupSource(key, empty); origin != "" {
+			return value, origin, nil
+		}
+	}
+
+	if name, ok := e.src.Lookup(key + e.fileSuffix); ok && (!empty || !isBlank(name)) {
+		value, err := e.readFile(name)
+		if err != nil {
+			return "", "", &Error{Key: key + e.fileSuffix, Value: name, Constraint: err.Error(), Err: ErrFile}
+		}
+
+		if !empty || !isBlank(value) {
+			return value, OriginFile, nil
+		}
+	}
+
+	if e.fileFirst {
+		return e.lookupSource(key, empty)
+	}
+
+	return "", "", nil
+}
+
+func (e *Env) lookupSource(key string, empty bool) (string, string, error) {
+	value, ok := e.src.Lookup(key)
+	if !ok || empty && isBlank(value) {
+		return "", "", nil
+	}
+
+	return value, OriginSource, nil
+}
+
+func (e *Env) readFile(name string) (string, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	b, err := io.ReadAll(io.LimitReader(f, e.fileMaxSize+1))
+	if err != nil {
+		return "", err
+	}
+
+	if int64(len(b)) > e.fileMaxSize {
+		return "", fmt.Errorf("file is larger than %d bytes", e.fileMaxSize)
+	}
+
+	return strings.TrimRight(string(b), "\r\n"), nil
+}
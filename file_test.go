@@ -0,0 +1,141 @@
+package env_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gomodrepo/env"
+)
+
+func TestWithFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	secret := filepath.Join(dir, "secret")
+	os.WriteFile(secret, []byte("s3cr3t\n\n"), 0o600)
+
+	large := filepath.Join(dir, "large")
+	os.WriteFile(large, []byte(strings.Repeat("x", 64)), 0o600)
+
+	blank := filepath.Join(dir, "blank")
+	os.WriteFile(blank, []byte("\n"), 0o600)
+
+	scenarios := []struct {
+		desc      string
+		src       env.Map
+		opts      []env.Option
+		wantValue string
+		wantErr   error
+	}{
+		{
+			desc:    "#00",
+			src:     env.Map{"PASSWORD_FILE": secret},
+			wantErr: env.ErrNotSet,
+		},
+		{
+			desc:      "#01",
+			src:       env.Map{"PASSWORD_FILE": secret},
+			opts:      []env.Option{env.WithFiles("_FILE")},
+			wantValue: "s3cr3t",
+		},
+		{
+			desc:      "#02",
+			src:       env.Map{"PASSWORD": "plain", "PASSWORD_FILE": secret},
+			opts:      []env.Option{env.WithFiles("_FILE")},
+			wantValue: "plain",
+		},
+		{
+			desc:      "#03",
+			src:       env.Map{"PASSWORD": "plain", "PASSWORD_FILE": secret},
+			opts:      []env.Option{env.WithFiles("_FILE"), env.WithFilePrecedence(env.FileFirst)},
+			wantValue: "s3cr3t",
+		},
+		{
+			desc:      "#04",
+			src:       env.Map{"PASSWORD": "plain"},
+			opts:      []env.Option{env.WithFiles("_FILE"), env.WithFilePrecedence(env.FileFirst)},
+			wantValue: "plain",
+		},
+		{
+			desc:    "#05",
+			src:     env.Map{"PASSWORD_FILE": filepath.Join(dir, "missing")},
+			opts:    []env.Option{env.WithFiles("_FILE")},
+			wantErr: env.ErrFile,
+		},
+		{
+			desc:    "#06",
+			src:     env.Map{"PASSWORD_FILE": large},
+			opts:    []env.Option{env.WithFiles("_FILE"), env.WithFileMaxSize(32)},
+			wantErr: env.ErrFile,
+		},
+		{
+			desc:      "#07",
+			src:       env.Map{"PASSWORD": "", "PASSWORD_FILE": secret},
+			opts:      []env.Option{env.WithFiles("_FILE"), env.WithEmptyAsUnset()},
+			wantValue: "s3cr3t",
+		},
+		{
+			desc:      "#08",
+			src:       env.Map{"PASSWORD": "plain", "PASSWORD_FILE": blank},
+			opts:      []env.Option{env.WithFiles("_FILE"), env.WithFilePrecedence(env.FileFirst), env.WithEmptyAsUnset()},
+			wantValue: "plain",
+		},
+		{
+			desc:    "#09",
+			src:     env.Map{"PASSWORD": " ", "PASSWORD_FILE": blank},
+			opts:    []env.Option{env.WithFiles("_FILE"), env.WithEmptyAsUnset()},
+			wantErr: env.ErrNotSet,
+		},
+		{
+			desc:    "#10",
+			src:     env.Map{"PASSWORD_FILE": ""},
+			opts:    []env.Option{env.WithFiles("_FILE"), env.WithEmptyAsUnset()},
+			wantErr: env.ErrNotSet,
+		},
+		{
+			desc:      "#11",
+			src:       env.Map{"PASSWORD": "plain", "PASSWORD_FILE": " "},
+			opts:      []env.Option{env.WithFiles("_FILE"), env.WithFilePrecedence(env.FileFirst), env.WithEmptyAsUnset()},
+			wantValue: "plain",
+		},
+		{
+			desc:    "#12",
+			src:     env.Map{"PASSWORD_FILE": ""},
+			opts:    []env.Option{env.WithFiles("_FILE")},
+			wantErr: env.ErrFile,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run("WithFiles", func(t *testing.T) {
+			e := env.New(s.src, s.opts...)
+
+			got, err := e.Lookup("PASSWORD")
+			if got != s.wantValue || !errors.Is(err, s.wantErr) {
+				t.Errorf("%v: got '%v' '%v' want '%v' '%v'", s.desc, got, err, s.wantValue, s.wantErr)
+			}
+
+			wantGet := s.wantValue
+			if s.wantErr != nil {
+				wantGet = _defaultValue
+			}
+			if got := e.GetExcept("PASSWORD", _defaultValue, "x"); got != wantGet {
+				t.Errorf("%v: got '%v' want '%v'", s.desc, got, wantGet)
+			}
+		})
+	}
+}
+
+func TestWith(t *testing.T) {
+	base := env.New(env.Map{"A_FILE": "/nonexistent"})
+	e := base.With(env.WithFiles("_FILE"))
+
+	if _, err := base.Lookup("A"); !errors.Is(err, env.ErrNotSet) {
+		t.Errorf("got error '%v' want '%v'", err, env.ErrNotSet)
+	}
+	if _, err := e.Lookup("A"); !errors.Is(err, env.ErrFile) {
+		t.Errorf("got error '%v' want '%v'", err, env.ErrFile)
+	}
+}
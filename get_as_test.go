@@ -0,0 +1,136 @@
+package env_test
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gomodrepo/env"
+)
+
+func TestGetAs(t *testing.T) {
+	scenarios := []struct {
+		desc     string
+		setKey   string
+		setValue string
+		get      func() any
+		want     any
+	}{
+		{
+			desc: "#00",
+			get:  func() any { return env.GetInt(_testKey, 8080) },
+			want: 8080,
+		},
+		{
+			desc:     "#01",
+			setKey:   _testKey,
+			setValue: "9090",
+			get:      func() any { return env.GetInt(_testKey, 8080) },
+			want:     9090,
+		},
+		{
+			desc:     "#02",
+			setKey:   _testKey,
+			setValue: "port",
+			get:      func() any { return env.GetInt(_testKey, 8080) },
+			want:     8080,
+		},
+		{
+			desc:     "#03",
+			setKey:   _testKey,
+			setValue: "-1",
+			get:      func() any { return env.GetUint(_testKey, 1) },
+			want:     uint(1),
+		},
+		{
+			desc:     "#04",
+			setKey:   _testKey,
+			setValue: "true",
+			get:      func() any { return env.GetBool(_testKey, false) },
+			want:     true,
+		},
+		{
+			desc:     "#05",
+			setKey:   _testKey,
+			setValue: "1.5",
+			get:      func() any { return env.GetFloat64(_testKey, 0) },
+			want:     1.5,
+		},
+		{
+			desc:     "#06",
+			setKey:   _testKey,
+			setValue: "1m30s",
+			get:      func() any { return env.GetDuration(_testKey, time.Second) },
+			want:     90 * time.Second,
+		},
+		{
+			desc:     "#07",
+			setKey:   _testKey,
+			setValue: "90",
+			get:      func() any { return env.GetDuration(_testKey, time.Second) },
+			want:     time.Second,
+		},
+		{
+			desc:     "#08",
+			setKey:   _testKey,
+			setValue: "300",
+			get:      func() any { return env.GetAs[int8](_testKey, 1) },
+			want:     int8(1),
+		},
+		{
+			desc:     "#09",
+			setKey:   _testKey,
+			setValue: "2",
+			get:      func() any { return env.GetAsIn(_testKey, 1, 2, 3) },
+			want:     2,
+		},
+		{
+			desc:     "#10",
+			setKey:   _testKey,
+			setValue: "4",
+			get:      func() any { return env.GetAsIn(_testKey, 1, 2, 3) },
+			want:     1,
+		},
+		{
+			desc:     "#11",
+			setKey:   _testKey,
+			setValue: "2",
+			get:      func() any { return env.GetAsExcept(_testKey, 1, 2, 3) },
+			want:     1,
+		},
+		{
+			desc:     "#12",
+			setKey:   _testKey,
+			setValue: "4",
+			get:      func() any { return env.GetAsExcept(_testKey, 1, 2, 3) },
+			want:     4,
+		},
+		{
+			desc:     "#13",
+			setKey:   _testKey,
+			setValue: "info",
+			get:      func() any { return env.GetAsIn(_testKey, "warn", "info", "warn") },
+			want:     "info",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run("GetAs", func(t *testing.T) {
+			backup, ok := os.LookupEnv(s.setKey)
+			defer func() {
+				if ok {
+					os.Setenv(s.setKey, backup)
+				} else {
+					os.Unsetenv(s.setKey)
+				}
+			}()
+
+			os.Setenv(s.setKey, s.setValue)
+
+			got := s.get()
+			if got != s.want {
+				t.Errorf("%v: got '%v' want '%v'", s.desc, got, s.want)
+			}
+		})
+	}
+}
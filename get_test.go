@@ -59,3 +59,13 @@ func TestGet(t *testing.T) {
 		})
 	}
 }
+
+func BenchmarkGet(b *testing.B) {
+	os.Setenv(_testKey, _testValue)
+	defer os.Unsetenv(_testKey)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		env.Get(_testKey, _defaultValue)
+	}
+}
@@ -0,0 +1,189 @@
+package env
+
+import (
+	"path"
+	"strings"
+)
+
+// GetInGlob is like the package-level GetInGlob but reads from the source of 'e'.
+func (e *Env) GetInGlob(key, defaultValue string, glob ...string) string {
+	return e.Var(key).Default(defaultValue).Glob(glob...).String()
+}
+
+// GetInGlobCaseInsensitive is like the package-level GetInGlobCaseInsensitive but reads from the source of 'e'.
+func (e *Env) GetInGlobCaseInsensitive(key, defaultValue string, glob ...string) string {
+	return e.Var(key).Default(defaultValue).Glob(glob...).CaseInsensitive().String()
+}
+
+// GetExceptGlob is like the package-level GetExceptGlob but reads from the source of 'e'.
+func (e *Env) GetExceptGlob(key, defaultValue string, glob ...string) string {
+	return e.Var(key).Default(defaultValue).NotGlob(glob...).String()
+}
+
+// GetExceptGlobCaseInsensitive is like the package-level GetExceptGlobCaseInsensitive but reads from the source of 'e'.
+func (e *Env) GetExceptGlobCaseInsensitive(key, defaultValue string, glob ...string) string {
+	return e.Var(key).Default(defaultValue).NotGlob(glob...).CaseInsensitive().String()
+}
+
+// LookupInGlob is like the package-level LookupInGlob but reads from the source of 'e'.
+func (e *Env) LookupInGlob(key string, glob ...string) (string, error) {
+	return e.Var(key).Glob(glob...).Lookup()
+}
+
+// LookupInGlobCaseInsensitive is like the package-level LookupInGlobCaseInsensitive but reads from the source of 'e'.
+func (e *Env) LookupInGlobCaseInsensitive(key string, glob ...string) (string, error) {
+	return e.Var(key).Glob(glob...).CaseInsensitive().Lookup()
+}
+
+// LookupExceptGlob is like the package-level LookupExceptGlob but reads from the source of 'e'.
+func (e *Env) LookupExceptGlob(key string, glob ...string) (string, error) {
+	return e.Var(key).NotGlob(glob...).Lookup()
+}
+
+// LookupExceptGlobCaseInsensitive is like the package-level LookupExceptGlobCaseInsensitive but reads from the source of 'e'.
+func (e *Env) LookupExceptGlobCaseInsensitive(key string, glob ...string) (string, error) {
+	return e.Var(key).NotGlob(glob...).CaseInsensitive().Lookup()
+}
+
+// MustGetInGlob is like the package-level MustGetInGlob but reads from the source of 'e'.
+func (e *Env) MustGetInGlob(key string, glob ...string) string {
+	return must(e.LookupInGlob(key, glob...))
+}
+
+// MustGetInGlobCaseInsensitive is like the package-level MustGetInGlobCaseInsensitive but reads from the source of 'e'.
+func (e *Env) MustGetInGlobCaseInsensitive(key string, glob ...string) string {
+	return must(e.LookupInGlobCaseInsensitive(key, glob...))
+}
+
+// MustGetExceptGlob is like the package-level MustGetExceptGlob but reads from the source of 'e'.
+func (e *Env) MustGetExceptGlob(key string, glob ...string) string {
+	return must(e.LookupExceptGlob(key, glob...))
+}
+
+// MustGetExceptGlobCaseInsensitive is like the package-level MustGetExceptGlobCaseInsensitive but reads from the source of 'e'.
+func (e *Env) MustGetExceptGlobCaseInsensitive(key string, glob ...string) string {
+	return must(e.LookupExceptGlobCaseInsensitive(key, glob...))
+}
+
+// GetInGlob returns the environment variable set to 'key'.
+// If value is not set for 'key' or does not match any of the glob patterns 'glob', it returns 'defaultValue'.
+//
+// Glob patterns follow path.Match: '*' matches any sequence of characters other
+// than '/', '?' matches any single character other than '/', '[...]' matches a
+// character class and '\' escapes the next character. A pattern must match the
+// whole value, so "eu-*" matches "eu-west" but not "us-eu-west".
+func GetInGlob(key, defaultValue string, glob ...string) string {
+	return std.GetInGlob(key, defaultValue, glob...)
+}
+
+// GetInGlobCaseInsensitive returns the environment variable set to 'key'.
+// If value is not set for 'key' or does not match any of the glob patterns 'glob', it returns 'defaultValue'.
+// 'glob' is not case sensitive.
+func GetInGlobCaseInsensitive(key, defaultValue string, glob ...string) string {
+	return std.GetInGlobCaseInsensitive(key, defaultValue, glob...)
+}
+
+// GetExceptGlob returns the environment variable set to 'key'.
+// If value is not set for 'key' or matches one of the glob patterns 'glob', it returns 'defaultValue'.
+func GetExceptGlob(key, defaultValue string, glob ...string) string {
+	return std.GetExceptGlob(key, defaultValue, glob...)
+}
+
+// GetExceptGlobCaseInsensitive returns the environment variable set to 'key'.
+// If value is not set for 'key' or matches one of the glob patterns 'glob', it returns 'defaultValue'.
+// 'glob' is not case sensitive.
+func GetExceptGlobCaseInsensitive(key, defaultValue string, glob ...string) string {
+	return std.GetExceptGlobCaseInsensitive(key, defaultValue, glob...)
+}
+
+// LookupInGlob returns the environment variable set to 'key'.
+// If value is not set for 'key' or does not match any of the glob patterns 'glob', it returns an *Error.
+func LookupInGlob(key string, glob ...string) (string, error) {
+	return std.LookupInGlob(key, glob...)
+}
+
+// LookupInGlobCaseInsensitive returns the environment variable set to 'key'.
+// If value is not set for 'key' or does not match any of the glob patterns 'glob', it returns an *Error.
+// 'glob' is not case sensitive.
+func LookupInGlobCaseInsensitive(key string, glob ...string) (string, error) {
+	return std.LookupInGlobCaseInsensitive(key, glob...)
+}
+
+// LookupExceptGlob returns the environment variable set to 'key'.
+// If value is not set for 'key' or matches one of the glob patterns 'glob', it returns an *Error.
+func LookupExceptGlob(key string, glob ...string) (string, error) {
+	return std.LookupExceptGlob(key, glob...)
+}
+
+// LookupExceptGlobCaseInsensitive returns the environment variable set to 'key'.
+// If value is not set for 'key' or matches one of the glob patterns 'glob', it returns an *Error.
+// 'glob' is not case sensitive.
+func LookupExceptGlobCaseInsensitive(key string, glob ...string) (string, error) {
+	return std.LookupExceptGlobCaseInsensitive(key, glob...)
+}
+
+// MustGetInGlob returns the environment variable set to 'key'.
+// If value is not set for 'key' or does not match any of the glob patterns 'glob', it raises a panic.
+func MustGetInGlob(key string, glob ...string) string {
+	return std.MustGetInGlob(key, glob...)
+}
+
+// MustGetInGlobCaseInsensitive returns the environment variable set to 'key'.
+// If value is not set for 'key' or does not match any of the glob patterns 'glob', it raises a panic.
+// 'glob' is not case sensitive.
+func MustGetInGlobCaseInsensitive(key string, glob ...string) string {
+	return std.MustGetInGlobCaseInsensitive(key, glob...)
+}
+
+// MustGetExceptGlob returns the environment variable set to 'key'.
+// If value is not set for 'key' or matches one of the glob patterns 'glob', it raises a panic.
+func MustGetExceptGlob(key string, glob ...string) string {
+	return std.MustGetExceptGlob(key, glob...)
+}
+
+// MustGetExceptGlobCaseInsensitive returns the environment variable set to 'key'.
+// If value is not set for 'key' or matches one of the glob patterns 'glob', it raises a panic.
+// 'glob' is not case sensitive.
+func MustGetExceptGlobCaseInsensitive(key string, glob ...string) string {
+	return std.MustGetExceptGlobCaseInsensitive(key, glob...)
+}
+
+// checkInGlob returns an error unless 'value' matches one of the glob patterns 'glob'.
+func checkInGlob(key, value string, glob []string, fold bool) error {
+	for _, g := range glob {
+		ok, err := matchGlob(g, value, fold)
+		if err != nil {
+			return &Error{Key: key, Value: value, Constraint: constraint("glob", fold, []string{g}), Err: ErrBadPattern}
+		}
+
+		if ok {
+			return nil
+		}
+	}
+
+	return &Error{Key: key, Value: value, Constraint: constraint("glob", fold, glob), Err: ErrNotAllowed}
+}
+
+// checkExceptGlob returns an error if 'value' matches one of the glob patterns 'glob'.
+func checkExceptGlob(key, value string, glob []string, fold bool) error {
+	for _, g := range glob {
+		ok, err := matchGlob(g, value, fold)
+		if err != nil {
+			return &Error{Key: key, Value: value, Constraint: constraint("except-glob", fold, []string{g}), Err: ErrBadPattern}
+		}
+
+		if ok {
+			return &Error{Key: key, Value: value, Constraint: constraint("except-glob", fold, glob), Err: ErrExcluded}
+		}
+	}
+
+	return nil
+}
+
+func matchGlob(pattern, value string, fold bool) (bool, error) {
+	if fold {
+		pattern, value = strings.ToLower(pattern), strings.ToLower(value)
+	}
+
+	return path.Match(pattern, value)
+}
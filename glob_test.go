@@ -0,0 +1,108 @@
+package env_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gomodrepo/env"
+)
+
+func TestGlob(t *testing.T) {
+	e := env.New(env.Map{
+		"REGION": "eu-west-1",
+		"HOST":   "API.Internal",
+		"TEST":   "eu-test",
+	})
+
+	scenarios := []struct {
+		desc      string
+		lookup    func() (string, error)
+		wantValue string
+		wantErr   error
+	}{
+		{
+			desc:      "#00",
+			lookup:    func() (string, error) { return e.LookupInGlob("REGION", "us-*", "eu-*") },
+			wantValue: "eu-west-1",
+		},
+		{
+			desc:    "#01",
+			lookup:  func() (string, error) { return e.LookupInGlob("REGION", "west-*") },
+			wantErr: env.ErrNotAllowed,
+		},
+		{
+			desc:    "#02",
+			lookup:  func() (string, error) { return e.LookupInGlob("HOST", "*.internal") },
+			wantErr: env.ErrNotAllowed,
+		},
+		{
+			desc:      "#03",
+			lookup:    func() (string, error) { return e.LookupInGlobCaseInsensitive("HOST", "*.internal") },
+			wantValue: "API.Internal",
+		},
+		{
+			desc:    "#04",
+			lookup:  func() (string, error) { return e.LookupExceptGlob("TEST", "*-test") },
+			wantErr: env.ErrExcluded,
+		},
+		{
+			desc:    "#05",
+			lookup:  func() (string, error) { return e.LookupExceptGlobCaseInsensitive("HOST", "api.*") },
+			wantErr: env.ErrExcluded,
+		},
+		{
+			desc:    "#06",
+			lookup:  func() (string, error) { return e.LookupInGlob("REGION", "eu-[") },
+			wantErr: env.ErrBadPattern,
+		},
+		{
+			desc:    "#07",
+			lookup:  func() (string, error) { return e.LookupInGlob(_testKey, "*") },
+			wantErr: env.ErrNotSet,
+		},
+		{
+			desc:      "#08",
+			lookup:    func() (string, error) { return e.LookupInGlob("REGION", "eu-????-?") },
+			wantValue: "eu-west-1",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run("Glob", func(t *testing.T) {
+			got, err := s.lookup()
+			if got != s.wantValue || !errors.Is(err, s.wantErr) {
+				t.Errorf("%v: got '%v', '%v' want '%v', '%v'", s.desc, got, err, s.wantValue, s.wantErr)
+			}
+		})
+	}
+
+	if got := e.GetExceptGlob("TEST", "eu-west-1", "*-test"); got != "eu-west-1" {
+		t.Errorf("got '%v' want '%v'", got, "eu-west-1")
+	}
+
+	if got := e.GetInGlobCaseInsensitive("HOST", "localhost", "api.*"); got != "API.Internal" {
+		t.Errorf("got '%v' want '%v'", got, "API.Internal")
+	}
+
+	for _, err := range []error{
+		func() error { _, err := e.LookupInGlob("REGION", "eu-["); return err }(),
+		func() error { _, err := e.LookupExceptGlobCaseInsensitive("REGION", "eu-["); return err }(),
+	} {
+		if want := "env: failed to compile glob: REGION"; err == nil || !strings.HasPrefix(err.Error(), want) {
+			t.Errorf("got '%v' want it to start with '%v'", err, want)
+		}
+	}
+
+	if _, err := e.LookupInRegex("REGION", "eu-["); err == nil || !strings.HasPrefix(err.Error(), "env: failed to compile regex: REGION") {
+		t.Errorf("got '%v' want a regex error", err)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("got no panic")
+		}
+	}()
+
+	e.MustGetInGlob("TEST", "us-*")
+}
@@ -0,0 +1,108 @@
+package env
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"strings"
+)
+
+// Setting is the effective configuration of a variable, as served by Registry.Handler.
+type Setting struct {
+	Key         string   `json:"key"`
+	Description string   `json:"description,omitempty"`
+	Value       string   `json:"value"`
+	Read        bool     `json:"read"`
+	Set         bool     `json:"set"`
+	Defaulted   bool     `json:"defaulted"`
+	Origin      string   `json:"origin,omitempty"`
+	Secret      bool     `json:"secret"`
+	Default     string   `json:"default,omitempty"`
+	Required    bool     `json:"required"`
+	Constraints []string `json:"constraints,omitempty"`
+	Error       string   `json:"error,omitempty"`
+}
+
+// Settings returns the effective configuration of the declared variables sorted by key,
+// with the values of secret variables hidden.
+func (r *Registry) Settings() []Setting {
+	decls := r.Decls()
+
+	settings := make([]Setting, len(decls))
+	for i, d := range decls {
+		s := Setting{
+			Key:         d.Key,
+			Description: d.Description,
+			Secret:      d.Secret,
+			Required:    d.Required,
+			Constraints: d.constraints(),
+		}
+
+		if d.HasDefault {
+			s.Default = d.Default
+			if d.Secret {
+				s.Default = redacted
+			}
+		}
+
+		if ev, ok := r.LastRead(d.Key); ok {
+			s.Read, s.Set, s.Defaulted, s.Origin = true, ev.Set, ev.Defaulted, ev.Origin
+			s.Value = ev.Value
+
+			err := ev.Err
+			if d.Secret {
+				if s.Value != "" {
+					s.Value = redacted
+				}
+
+				err = redact(err)
+			}
+
+			if err != nil {
+				s.Error = err.Error()
+			}
+		}
+
+		settings[i] = s
+	}
+
+	return settings
+}
+
+// Handler returns an http.Handler serving the effective configuration of the
+// declared variables, as in Settings. It serves JSON when the request has the
+// query parameter format=json or accepts application/json, and an HTML table otherwise.
+//
+//	http.Handle("/debug/env", env.DefaultRegistry.Handler())
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		settings := r.Settings()
+
+		if req.URL.Query().Get("format") == "json" || strings.Contains(req.Header.Get("Accept"), "application/json") {
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			json.NewEncoder(w).Encode(settings)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		settingsTemplate.Execute(w, settings)
+	})
+}
+
+// Handler returns an http.Handler serving the effective configuration of the
+// variables read by the package-level functions. See Registry.Handler.
+func Handler() http.Handler {
+	return DefaultRegistry.Handler()
+}
+
+var settingsTemplate = template.Must(template.New("settings").Parse(`<!DOCTYPE html>
+<html>
+<head><title>Environment</title></head>
+<body>
+<table>
+<tr><th>Key</th><th>Value</th><th>Origin</th><th>Defaulted</th><th>Constraints</th><th>Description</th><th>Error</th></tr>
+{{range .}}<tr><td>{{.Key}}</td><td>{{if .Read}}{{.Value}}{{end}}</td><td>{{if .Read}}{{or .Origin "not set"}}{{else}}not read{{end}}</td><td>{{.Defaulted}}</td><td>{{range $i, $c := .Constraints}}{{if $i}}; {{end}}{{$c}}{{end}}</td><td>{{.Description}}</td><td>{{.Error}}</td></tr>
+{{end}}</table>
+</body>
+</html>
+`))
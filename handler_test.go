@@ -0,0 +1,77 @@
+package env_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gomodrepo/env"
+)
+
+func TestHandler(t *testing.T) {
+	r := env.NewRegistry()
+	e := env.New(env.Map{
+		"MODE":     "prod",
+		"PASSWORD": "hunter2",
+		"LEVEL":    "<script>",
+	}, env.WithRegistry(r))
+
+	e.GetIn("MODE", "dev", "dev", "prod")
+	e.GetInt("PORT", 8080)
+	_ = e.Var("PASSWORD").Describe("Database password.").Default("changeme").Secret().String()
+	e.GetIn("LEVEL", "info", "info", "debug")
+	e.Var("TOKEN").Describe("API token.").Declare()
+
+	srv := httptest.NewServer(r.Handler())
+	defer srv.Close()
+
+	res, err := http.Get(srv.URL + "?format=json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	var got []env.Setting
+	if err := json.NewDecoder(res.Body).Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]env.Setting{
+		"LEVEL":    {Key: "LEVEL", Value: "info", Read: true, Set: true, Defaulted: true, Origin: env.OriginDefault, Default: "info"},
+		"MODE":     {Key: "MODE", Value: "prod", Read: true, Set: true, Origin: env.OriginSource, Default: "dev"},
+		"PASSWORD": {Key: "PASSWORD", Value: "(hidden)", Read: true, Set: true, Origin: env.OriginSource, Secret: true, Default: "(hidden)"},
+		"PORT":     {Key: "PORT", Value: "8080", Read: true, Defaulted: true, Origin: env.OriginDefault, Default: "8080"},
+		"TOKEN":    {Key: "TOKEN"},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("got '%+v' want %d settings", got, len(want))
+	}
+
+	for _, s := range got {
+		w := want[s.Key]
+		if s.Value != w.Value || s.Read != w.Read || s.Set != w.Set || s.Defaulted != w.Defaulted || s.Origin != w.Origin || s.Secret != w.Secret || s.Default != w.Default {
+			t.Errorf("got '%+v' want '%+v'", s, w)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	r.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/env", nil))
+
+	body := rec.Body.String()
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/html") {
+		t.Errorf("got content type '%v' want 'text/html'", ct)
+	}
+
+	for _, s := range []string{"<td>MODE</td>", "not read", "Database password.", "&lt;script&gt;"} {
+		if !strings.Contains(body, s) {
+			t.Errorf("got body\n%v\nwant it to contain '%v'", body, s)
+		}
+	}
+
+	if strings.Contains(body, "hunter2") || strings.Contains(body, "<script>") {
+		t.Errorf("got body\n%v\nwant secrets hidden and values escaped", body)
+	}
+}
@@ -0,0 +1,389 @@
+package env
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ElementError reports the element of a list or map variable that was rejected.
+type ElementError struct {
+	// Index is the position of the element in the value.
+	Index int
+	// Err is the reason the element was rejected.
+	Err *Error
+}
+
+func (e *ElementError) Error() string {
+	return fmt.Sprintf("%s (element %d)", e.Err.Error(), e.Index)
+}
+
+func (e *ElementError) Unwrap() error {
+	return e.Err
+}
+
+// Separator sets the separator of the elements of a list or of the entries of a map, "," by default.
+func (v *Variable) Separator(sep string) *Variable {
+	v.sep = sep
+	return v
+}
+
+// KeyValueSeparator sets the separator of the keys and values of a map, "=" by default.
+func (v *Variable) KeyValueSeparator(sep string) *Variable {
+	v.kvSep = sep
+	return v
+}
+
+// KeepSpace keeps the spaces around the elements of a list or map, which are trimmed by default.
+func (v *Variable) KeepSpace() *Variable {
+	v.keepSpace = true
+	return v
+}
+
+// Unique removes the repeated elements of a list, keeping the first occurrence.
+// Elements are compared once aliases and Canonical are applied, ignoring case if the
+// variable is CaseInsensitive.
+func (v *Variable) Unique() *Variable {
+	v.unique = true
+	return v
+}
+
+// List returns the value of the variable split into a list.
+//
+// Elements are separated by Separator and can be enclosed in double quotes to
+// hold the separator, a backslash escaping the next character inside quotes.
+// Every element must satisfy the constraints of the variable.
+//
+// If the variable is not set or one of its elements is rejected, it returns the
+// default value split into a list.
+func (v *Variable) List() []string {
+	list, err := v.LookupList()
+	if err != nil {
+		list, _ = v.splitList(v.def)
+	}
+
+	return list
+}
+
+// LookupList is like List but returns an error instead of the default value.
+// Rejected elements are reported with an *ElementError.
+func (v *Variable) LookupList() ([]string, error) {
+	var list []string
+	r := v.evaluate(func(value string) (string, error) {
+		var err error
+		list, err = v.parseList(value)
+		return value, err
+	})
+
+	switch {
+	case r.Err != nil:
+		return nil, r.Err
+	case r.Defaulted:
+		return v.splitList(v.def)
+	}
+
+	return list, nil
+}
+
+// MustList is like LookupList but raises a panic with the error instead of returning it.
+func (v *Variable) MustList() []string {
+	return must(v.LookupList())
+}
+
+// Map returns the value of the variable split into a map.
+//
+// Entries are separated by Separator and hold a key and a value separated by
+// KeyValueSeparator, e.g. "team=core,tier=1". Quoting follows List.
+// Every value must satisfy the constraints of the variable and, when a key is
+// repeated, the last entry wins.
+//
+// If the variable is not set or one of its values is rejected, it returns the
+// default value split into a map.
+func (v *Variable) Map() map[string]string {
+	m, err := v.LookupMap()
+	if err != nil {
+		m, _ = v.splitMap(v.def)
+	}
+
+	return m
+}
+
+// LookupMap is like Map but returns an error instead of the default value.
+// Rejected entries are reported with an *ElementError.
+func (v *Variable) LookupMap() (map[string]string, error) {
+	var m map[string]string
+	r := v.evaluate(func(value string) (string, error) {
+		var err error
+		m, err = v.parseMap(value)
+		return value, err
+	})
+
+	switch {
+	case r.Err != nil:
+		return nil, r.Err
+	case r.Defaulted:
+		return v.splitMap(v.def)
+	}
+
+	return m, nil
+}
+
+// MustMap is like LookupMap but raises a panic with the error instead of returning it.
+func (v *Variable) MustMap() map[string]string {
+	return must(v.LookupMap())
+}
+
+// GetList is like the package-level GetList but reads from the source of 'e'.
+func (e *Env) GetList(key string, defaultValue []string) []string {
+	list, err := e.Var(key).LookupList()
+	if err != nil {
+		return defaultValue
+	}
+
+	return list
+}
+
+// MustGetList is like the package-level MustGetList but reads from the source of 'e'.
+func (e *Env) MustGetList(key string) []string {
+	return e.Var(key).MustList()
+}
+
+// GetMap is like the package-level GetMap but reads from the source of 'e'.
+func (e *Env) GetMap(key string, defaultValue map[string]string) map[string]string {
+	m, err := e.Var(key).LookupMap()
+	if err != nil {
+		return defaultValue
+	}
+
+	return m
+}
+
+// MustGetMap is like the package-level MustGetMap but reads from the source of 'e'.
+func (e *Env) MustGetMap(key string) map[string]string {
+	return e.Var(key).MustMap()
+}
+
+// GetList returns the environment variable set to 'key' split into a comma-separated list.
+// If value is not set for 'key' or is malformed, it returns 'defaultValue'.
+// Use Var for other separators and per-element constraints.
+func GetList(key string, defaultValue []string) []string {
+	return std.GetList(key, defaultValue)
+}
+
+// MustGetList returns the environment variable set to 'key' split into a comma-separated list.
+// If value is not set for 'key' or is malformed, it raises a panic.
+func MustGetList(key string) []string {
+	return std.MustGetList(key)
+}
+
+// GetMap returns the environment variable set to 'key' split into a map of comma-separated
+// key=value entries.
+// If value is not set for 'key' or is malformed, it returns 'defaultValue'.
+// Use Var for other separators and per-element constraints.
+func GetMap(key string, defaultValue map[string]string) map[string]string {
+	return std.GetMap(key, defaultValue)
+}
+
+// MustGetMap returns the environment variable set to 'key' split into a map of comma-separated
+// key=value entries.
+// If value is not set for 'key' or is malformed, it raises a panic.
+func MustGetMap(key string) map[string]string {
+	return std.MustGetMap(key)
+}
+
+// parseList splits 'value' into a list and validates its elements.
+func (v *Variable) parseList(value string) ([]string, error) {
+	list, err := v.split(value)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, el := range list {
+		el = v.unalias(el)
+		if err := v.validateElement(i, el); err != nil {
+			return nil, err
+		}
+
+		list[i] = v.normalize(el)
+	}
+
+	return v.dedupe(list), nil
+}
+
+// parseMap splits 'value' into a map and validates its values.
+func (v *Variable) parseMap(value string) (map[string]string, error) {
+	entries, err := v.split(value)
+	if err != nil {
+		return nil, err
+	}
+
+	m := make(map[string]string, len(entries))
+	for i, entry := range entries {
+		k, val, err := v.cutEntry(i, entry)
+		if err != nil {
+			return nil, err
+		}
+
+		val = v.unalias(val)
+		if err := v.validateElement(i, val); err != nil {
+			return nil, err
+		}
+
+		m[k] = v.normalize(val)
+	}
+
+	return m, nil
+}
+
+// validateElement applies the constraints of the variable to the element at 'index'.
+func (v *Variable) validateElement(index int, el string) error {
+	err := v.validate(el)
+	if err == nil {
+		return nil
+	}
+
+	var e *Error
+	if !errors.As(err, &e) {
+		return err
+	}
+
+	return &ElementError{Index: index, Err: e}
+}
+
+func (v *Variable) splitList(value string) ([]string, error) {
+	list, err := v.split(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return v.dedupe(list), nil
+}
+
+// dedupe removes the repeated elements of 'list' if the variable is Unique, keeping the first occurrence.
+func (v *Variable) dedupe(list []string) []string {
+	if !v.unique {
+		return list
+	}
+
+	seen := make(map[string]bool, len(list))
+	unique := list[:0]
+	for _, el := range list {
+		k := el
+		if v.fold {
+			k = strings.ToLower(el)
+		}
+
+		if !seen[k] {
+			seen[k] = true
+			unique = append(unique, el)
+		}
+	}
+
+	return unique
+}
+
+func (v *Variable) splitMap(value string) (map[string]string, error) {
+	entries, err := v.split(value)
+	if err != nil {
+		return nil, err
+	}
+
+	m := make(map[string]string, len(entries))
+	for i, entry := range entries {
+		k, val, err := v.cutEntry(i, entry)
+		if err != nil {
+			return nil, err
+		}
+
+		m[k] = val
+	}
+
+	return m, nil
+}
+
+// cutEntry splits the map entry at 'index' into its key and value.
+func (v *Variable) cutEntry(index int, entry string) (string, string, error) {
+	kvSep := v.kvSep
+	if kvSep == "" {
+		kvSep = "="
+	}
+
+	k, val, ok := strings.Cut(entry, kvSep)
+	if !ok {
+		return "", "", &ElementError{Index: index, Err: &Error{Key: v.key, Value: entry, Constraint: fmt.Sprintf("missing %q", kvSep), Err: ErrSyntax}}
+	}
+
+	if !v.keepSpace {
+		k, val = strings.TrimSpace(k), strings.TrimSpace(val)
+	}
+
+	return k, val, nil
+}
+
+// split splits 'value' into its elements, handling quotes and trimming.
+func (v *Variable) split(value string) ([]string, error) {
+	if value == "" {
+		return []string{}, nil
+	}
+
+	sep := v.sep
+	if sep == "" {
+		sep = ","
+	}
+
+	var (
+		list []string
+		b    strings.Builder
+		// kept is the length of the element that must not be trimmed,
+		// because it ends with quoted content.
+		kept   int
+		quoted bool
+	)
+
+	flush := func() {
+		el := b.String()
+		if !v.keepSpace {
+			el = el[:kept] + strings.TrimRightFunc(el[kept:], unicode.IsSpace)
+		}
+
+		list = append(list, el)
+		b.Reset()
+		kept = 0
+	}
+
+	for i := 0; i < len(value); {
+		switch c := value[i]; {
+		case quoted && c == '\\' && i+1 < len(value):
+			b.WriteByte(value[i+1])
+			kept = b.Len()
+			i += 2
+		case quoted && c == '"':
+			quoted = false
+			i++
+		case quoted:
+			b.WriteByte(c)
+			kept = b.Len()
+			i++
+		case c == '"':
+			quoted = true
+			kept = b.Len()
+			i++
+		case strings.HasPrefix(value[i:], sep):
+			flush()
+			i += len(sep)
+		case !v.keepSpace && b.Len() == 0 && unicode.IsSpace(rune(c)):
+			i++
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+
+	if quoted {
+		return nil, &ElementError{Index: len(list), Err: &Error{Key: v.key, Value: value, Constraint: "unterminated quote", Err: ErrSyntax}}
+	}
+
+	flush()
+	return list, nil
+}
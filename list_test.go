@@ -0,0 +1,189 @@
+package env_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/gomodrepo/env"
+)
+
+func TestList(t *testing.T) {
+	e := env.New(env.Map{
+		"ORIGINS": "a.com, b.com ,a.com",
+		"QUOTED":  `"x, y" , " z ",w\,"q\"r"`,
+		"PIPES":   "a|b||c",
+		"BAD":     `a,"b`,
+		"EMPTY":   "",
+		"L":       "a,A,b",
+	})
+
+	scenarios := []struct {
+		desc      string
+		v         *env.Variable
+		wantValue []string
+		wantErr   error
+		wantIndex int
+	}{
+		{
+			desc:      "#00",
+			v:         e.Var("ORIGINS"),
+			wantValue: []string{"a.com", "b.com", "a.com"},
+		},
+		{
+			desc:      "#01",
+			v:         e.Var("ORIGINS").Unique(),
+			wantValue: []string{"a.com", "b.com"},
+		},
+		{
+			desc:      "#02",
+			v:         e.Var("ORIGINS").KeepSpace(),
+			wantValue: []string{"a.com", " b.com ", "a.com"},
+		},
+		{
+			desc:      "#03",
+			v:         e.Var("QUOTED"),
+			wantValue: []string{"x, y", " z ", `w\`, `q"r`},
+		},
+		{
+			desc:      "#04",
+			v:         e.Var("PIPES").Separator("|"),
+			wantValue: []string{"a", "b", "", "c"},
+		},
+		{
+			desc:      "#05",
+			v:         e.Var("EMPTY"),
+			wantValue: []string{},
+		},
+		{
+			desc:    "#06",
+			v:       e.Var("MISSING"),
+			wantErr: env.ErrNotSet,
+		},
+		{
+			desc:      "#07",
+			v:         e.Var("MISSING").Default("x,y"),
+			wantValue: []string{"x", "y"},
+		},
+		{
+			desc:      "#08",
+			v:         e.Var("ORIGINS").OneOf("a.com", "c.com"),
+			wantErr:   env.ErrNotAllowed,
+			wantIndex: 1,
+		},
+		{
+			desc:      "#09",
+			v:         e.Var("ORIGINS").Match(`\.com$`).NotMatch(`^b`),
+			wantErr:   env.ErrExcluded,
+			wantIndex: 1,
+		},
+		{
+			desc:      "#10",
+			v:         e.Var("ORIGINS").OneOf("A.COM", "B.COM").CaseInsensitive(),
+			wantValue: []string{"a.com", "b.com", "a.com"},
+		},
+		{
+			desc:      "#11",
+			v:         e.Var("BAD"),
+			wantErr:   env.ErrSyntax,
+			wantIndex: 1,
+		},
+		{
+			desc:      "#12",
+			v:         e.Var("L").OneOf("a", "b").CaseInsensitive().Canonical().Unique(),
+			wantValue: []string{"a", "b"},
+		},
+		{
+			desc:      "#13",
+			v:         e.Var("L").CaseInsensitive().Unique(),
+			wantValue: []string{"a", "b"},
+		},
+		{
+			desc:      "#14",
+			v:         e.Var("L").Alias("b", "A").Unique(),
+			wantValue: []string{"a", "b"},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run("List", func(t *testing.T) {
+			got, err := s.v.LookupList()
+			if !reflect.DeepEqual(got, s.wantValue) || !errors.Is(err, s.wantErr) {
+				t.Errorf("%v: got '%q' '%v' want '%q' '%v'", s.desc, got, err, s.wantValue, s.wantErr)
+			}
+
+			var ee *env.ElementError
+			if errors.As(err, &ee) && ee.Index != s.wantIndex {
+				t.Errorf("%v: got index '%v' want '%v'", s.desc, ee.Index, s.wantIndex)
+			}
+		})
+	}
+
+	if got := e.Var("ORIGINS").Default("x").OneOf("x").List(); !reflect.DeepEqual(got, []string{"x"}) {
+		t.Errorf("got '%v' want '[x]'", got)
+	}
+	if got := e.GetList("MISSING", []string{"d"}); !reflect.DeepEqual(got, []string{"d"}) {
+		t.Errorf("got '%v' want '[d]'", got)
+	}
+
+	defer func() {
+		err, _ := recover().(error)
+		if err == nil || err.Error() != `env: value is not in: ORIGINS: value "b.com", in ["a.com"], did you mean "a.com"? (element 1)` {
+			t.Errorf("got panic '%v'", err)
+		}
+	}()
+
+	e.Var("ORIGINS").OneOf("a.com").MustList()
+}
+
+func TestMap(t *testing.T) {
+	e := env.New(env.Map{
+		"LABELS": `team=core, tier = 1 ,note="a=b,c"`,
+		"COLONS": "a:1;b:2",
+		"BAD":    "a=1,b",
+	})
+
+	scenarios := []struct {
+		desc      string
+		v         *env.Variable
+		wantValue map[string]string
+		wantErr   error
+	}{
+		{
+			desc:      "#00",
+			v:         e.Var("LABELS"),
+			wantValue: map[string]string{"team": "core", "tier": "1", "note": "a=b,c"},
+		},
+		{
+			desc:      "#01",
+			v:         e.Var("COLONS").Separator(";").KeyValueSeparator(":").OneOf("1", "2"),
+			wantValue: map[string]string{"a": "1", "b": "2"},
+		},
+		{
+			desc:    "#02",
+			v:       e.Var("LABELS").NotMatch(","),
+			wantErr: env.ErrExcluded,
+		},
+		{
+			desc:    "#03",
+			v:       e.Var("BAD"),
+			wantErr: env.ErrSyntax,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run("Map", func(t *testing.T) {
+			got, err := s.v.LookupMap()
+			if !reflect.DeepEqual(got, s.wantValue) || !errors.Is(err, s.wantErr) {
+				t.Errorf("%v: got '%v' '%v' want '%v' '%v'", s.desc, got, err, s.wantValue, s.wantErr)
+			}
+		})
+	}
+
+	if got := e.MustGetMap("LABELS"); got["tier"] != "1" {
+		t.Errorf("got '%v' want tier '1'", got)
+	}
+	if got := e.GetMap("BAD", nil); got != nil {
+		t.Errorf("got '%v' want 'nil'", got)
+	}
+}
@@ -0,0 +1,165 @@
+package env_test
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/gomodrepo/env"
+)
+
+func TestLookup(t *testing.T) {
+	scenarios := []struct {
+		desc           string
+		setKey         string
+		setValue       string
+		lookup         func() (string, error)
+		wantValue      string
+		wantErr        error
+		wantConstraint string
+	}{
+		{
+			desc:    "#00",
+			lookup:  func() (string, error) { return env.Lookup(_testKey) },
+			wantErr: env.ErrNotSet,
+		},
+		{
+			desc:      "#01",
+			setKey:    _testKey,
+			setValue:  _testValue,
+			lookup:    func() (string, error) { return env.Lookup(_testKey) },
+			wantValue: _testValue,
+		},
+		{
+			desc:      "#02",
+			setKey:    _testKey,
+			setValue:  _testValue,
+			lookup:    func() (string, error) { return env.LookupIn(_testKey, "a", _testValue) },
+			wantValue: _testValue,
+		},
+		{
+			desc:           "#03",
+			setKey:         _testKey,
+			setValue:       _testValue,
+			lookup:         func() (string, error) { return env.LookupIn(_testKey, "a", "testvalue") },
+			wantErr:        env.ErrNotAllowed,
+			wantConstraint: `in ["a" "testvalue"]`,
+		},
+		{
+			desc:      "#04",
+			setKey:    _testKey,
+			setValue:  _testValue,
+			lookup:    func() (string, error) { return env.LookupInCaseInsensitive(_testKey, "a", "testvalue") },
+			wantValue: _testValue,
+		},
+		{
+			desc:      "#05",
+			setKey:    _testKey,
+			setValue:  _testValue,
+			lookup:    func() (string, error) { return env.LookupInRegex(_testKey, "^test") },
+			wantValue: _testValue,
+		},
+		{
+			desc:           "#06",
+			setKey:         _testKey,
+			setValue:       _testValue,
+			lookup:         func() (string, error) { return env.LookupInRegex(_testKey, "^a", "(") },
+			wantErr:        env.ErrBadPattern,
+			wantConstraint: `regex ["("]`,
+		},
+		{
+			desc:           "#07",
+			setKey:         _testKey,
+			setValue:       _testValue,
+			lookup:         func() (string, error) { return env.LookupExcept(_testKey, _testValue) },
+			wantErr:        env.ErrExcluded,
+			wantConstraint: `except ["testValue"]`,
+		},
+		{
+			desc:           "#08",
+			setKey:         _testKey,
+			setValue:       _testValue,
+			lookup:         func() (string, error) { return env.LookupExceptCaseInsensitive(_testKey, "TESTVALUE") },
+			wantErr:        env.ErrExcluded,
+			wantConstraint: `except-case-insensitive ["TESTVALUE"]`,
+		},
+		{
+			desc:           "#09",
+			setKey:         _testKey,
+			setValue:       _testValue,
+			lookup:         func() (string, error) { return env.LookupExceptRegex(_testKey, "^a", "Value$") },
+			wantErr:        env.ErrExcluded,
+			wantConstraint: `except-regex ["^a" "Value$"]`,
+		},
+		{
+			desc:      "#10",
+			setKey:    _testKey,
+			setValue:  _testValue,
+			lookup:    func() (string, error) { return env.LookupExceptRegex(_testKey, "^a") },
+			wantValue: _testValue,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run("Lookup", func(t *testing.T) {
+			backup, ok := os.LookupEnv(s.setKey)
+			defer func() {
+				if ok {
+					os.Setenv(s.setKey, backup)
+				} else {
+					os.Unsetenv(s.setKey)
+				}
+			}()
+
+			os.Setenv(s.setKey, s.setValue)
+
+			got, err := s.lookup()
+			if got != s.wantValue {
+				t.Errorf("%v: got '%v' want '%v'", s.desc, got, s.wantValue)
+			}
+			if !errors.Is(err, s.wantErr) {
+				t.Errorf("%v: got error '%v' want '%v'", s.desc, err, s.wantErr)
+			}
+
+			var e *env.Error
+			if errors.As(err, &e) {
+				if e.Key != _testKey || e.Constraint != s.wantConstraint {
+					t.Errorf("%v: got key '%v' constraint '%v' want '%v' '%v'", s.desc, e.Key, e.Constraint, _testKey, s.wantConstraint)
+				}
+			}
+		})
+	}
+}
+
+func TestMustGetPanicsWithError(t *testing.T) {
+	os.Setenv(_testKey, _testValue)
+	defer os.Unsetenv(_testKey)
+
+	defer func() {
+		err, _ := recover().(error)
+
+		var e *env.Error
+		if !errors.As(err, &e) || !errors.Is(err, env.ErrNotAllowed) || e.Value != _testValue {
+			t.Errorf("got panic '%v' want *env.Error wrapping ErrNotAllowed", err)
+		}
+	}()
+
+	env.MustGetIn(_testKey, "a", "b")
+}
+
+func TestLookupAs(t *testing.T) {
+	os.Setenv(_testKey, "port")
+	defer os.Unsetenv(_testKey)
+
+	_, err := env.LookupAs[int](_testKey)
+	if !errors.Is(err, env.ErrInvalid) {
+		t.Errorf("got error '%v' want '%v'", err, env.ErrInvalid)
+	}
+
+	os.Setenv(_testKey, "3")
+
+	_, err = env.LookupAsIn(_testKey, 1, 2)
+	if !errors.Is(err, env.ErrNotAllowed) {
+		t.Errorf("got error '%v' want '%v'", err, env.ErrNotAllowed)
+	}
+}
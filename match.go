@@ -0,0 +1,223 @@
+package env
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+	"sync/atomic"
+)
+
+// Matcher reports whether a value matches a pattern.
+// *Pattern and *regexp.Regexp implement it.
+type Matcher interface {
+	MatchString(s string) bool
+}
+
+// Pattern is a compiled regular expression, safe for concurrent use.
+type Pattern struct {
+	re *regexp.Regexp
+}
+
+// Compile compiles the regular expression 'expr'.
+// If 'expr' is invalid, it returns an *Error wrapping ErrBadPattern.
+func Compile(expr string) (*Pattern, error) {
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, &Error{Value: expr, Constraint: err.Error(), Err: ErrBadPattern}
+	}
+
+	return &Pattern{re: re}, nil
+}
+
+// MustCompile is like Compile but raises a panic if 'expr' is invalid.
+func MustCompile(expr string) *Pattern {
+	return must(Compile(expr))
+}
+
+// MatchString reports whether 's' matches the pattern.
+func (p *Pattern) MatchString(s string) bool {
+	return p.re.MatchString(s)
+}
+
+// String returns the source of the pattern.
+func (p *Pattern) String() string {
+	return p.re.String()
+}
+
+// GetInMatcher is like the package-level GetInMatcher but reads from the source of 'e'.
+func (e *Env) GetInMatcher(key, defaultValue string, m ...Matcher) string {
+	return e.Var(key).Default(defaultValue).MatchWith(m...).String()
+}
+
+// GetExceptMatcher is like the package-level GetExceptMatcher but reads from the source of 'e'.
+func (e *Env) GetExceptMatcher(key, defaultValue string, m ...Matcher) string {
+	return e.Var(key).Default(defaultValue).NotMatchWith(m...).String()
+}
+
+// LookupInMatcher is like the package-level LookupInMatcher but reads from the source of 'e'.
+func (e *Env) LookupInMatcher(key string, m ...Matcher) (string, error) {
+	return e.Var(key).MatchWith(m...).Lookup()
+}
+
+// LookupExceptMatcher is like the package-level LookupExceptMatcher but reads from the source of 'e'.
+func (e *Env) LookupExceptMatcher(key string, m ...Matcher) (string, error) {
+	return e.Var(key).NotMatchWith(m...).Lookup()
+}
+
+// MustGetInMatcher is like the package-level MustGetInMatcher but reads from the source of 'e'.
+func (e *Env) MustGetInMatcher(key string, m ...Matcher) string {
+	return must(e.LookupInMatcher(key, m...))
+}
+
+// MustGetExceptMatcher is like the package-level MustGetExceptMatcher but reads from the source of 'e'.
+func (e *Env) MustGetExceptMatcher(key string, m ...Matcher) string {
+	return must(e.LookupExceptMatcher(key, m...))
+}
+
+// GetInMatcher returns the environment variable set to 'key'.
+// If value is not set for 'key' or does not match any of 'm', it returns 'defaultValue'.
+func GetInMatcher(key, defaultValue string, m ...Matcher) string {
+	return std.GetInMatcher(key, defaultValue, m...)
+}
+
+// GetExceptMatcher returns the environment variable set to 'key'.
+// If value is not set for 'key' or matches one of 'm', it returns 'defaultValue'.
+func GetExceptMatcher(key, defaultValue string, m ...Matcher) string {
+	return std.GetExceptMatcher(key, defaultValue, m...)
+}
+
+// LookupInMatcher returns the environment variable set to 'key'.
+// If value is not set for 'key' or does not match any of 'm', it returns an *Error.
+func LookupInMatcher(key string, m ...Matcher) (string, error) {
+	return std.LookupInMatcher(key, m...)
+}
+
+// LookupExceptMatcher returns the environment variable set to 'key'.
+// If value is not set for 'key' or matches one of 'm', it returns an *Error.
+func LookupExceptMatcher(key string, m ...Matcher) (string, error) {
+	return std.LookupExceptMatcher(key, m...)
+}
+
+// MustGetInMatcher returns the environment variable set to 'key'.
+// If value is not set for 'key' or does not match any of 'm', it raises a panic.
+func MustGetInMatcher(key string, m ...Matcher) string {
+	return std.MustGetInMatcher(key, m...)
+}
+
+// MustGetExceptMatcher returns the environment variable set to 'key'.
+// If value is not set for 'key' or matches one of 'm', it raises a panic.
+func MustGetExceptMatcher(key string, m ...Matcher) string {
+	return std.MustGetExceptMatcher(key, m...)
+}
+
+// checkInMatcher returns an error unless 'value' matches one of 'm'.
+func checkInMatcher(key, value string, m []Matcher) error {
+	for _, v := range m {
+		if v.MatchString(value) {
+			return nil
+		}
+	}
+
+	return &Error{Key: key, Value: value, Constraint: constraint("regex", false, matcherStrings(m)), Err: ErrNotAllowed}
+}
+
+// checkExceptMatcher returns an error if 'value' matches one of 'm'.
+func checkExceptMatcher(key, value string, m []Matcher) error {
+	for _, v := range m {
+		if v.MatchString(value) {
+			return &Error{Key: key, Value: value, Constraint: constraint("except-regex", false, matcherStrings(m)), Err: ErrExcluded}
+		}
+	}
+
+	return nil
+}
+
+func matcherStrings(m []Matcher) []string {
+	s := make([]string, len(m))
+	for i, v := range m {
+		s[i] = matcherString(v)
+	}
+
+	return s
+}
+
+// matcherString describes 'm', without formatting it if it is a fmt.Stringer.
+func matcherString(m Matcher) string {
+	if s, ok := m.(fmt.Stringer); ok {
+		return s.String()
+	}
+
+	return fmt.Sprint(m)
+}
+
+// regexCacheSize is the number of compiled expressions kept by the string-pattern functions.
+const regexCacheSize = 256
+
+var regexCache = newRegexClock(regexCacheSize)
+
+// compilations counts the calls to regexp.Compile made through the cache.
+var compilations uint64
+
+// regexClock is a bounded cache of compiled regular expressions. Hits are lock-free;
+// when full, an insert evicts an expression not used since the clock hand last passed it.
+type regexClock struct {
+	entries sync.Map // expression -> *regexEntry
+
+	// mu guards ring and hand, used on insert only.
+	mu   sync.Mutex
+	size int
+	ring []*regexEntry
+	hand int
+}
+
+type regexEntry struct {
+	expr string
+	re   *regexp.Regexp
+	err  error
+	// used is set to 1 on every hit and cleared by the clock hand.
+	used uint32
+}
+
+func newRegexClock(size int) *regexClock {
+	return &regexClock{size: size, ring: make([]*regexEntry, 0, size)}
+}
+
+// compile returns the compiled form of 'expr', compiling it on a cache miss.
+func (c *regexClock) compile(expr string) (*regexp.Regexp, error) {
+	if v, ok := c.entries.Load(expr); ok {
+		entry := v.(*regexEntry)
+		if atomic.LoadUint32(&entry.used) == 0 {
+			atomic.StoreUint32(&entry.used, 1)
+		}
+
+		return entry.re, entry.err
+	}
+
+	atomic.AddUint64(&compilations, 1)
+	re, err := regexp.Compile(expr)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if v, ok := c.entries.Load(expr); ok {
+		entry := v.(*regexEntry)
+		return entry.re, entry.err
+	}
+
+	entry := &regexEntry{expr: expr, re: re, err: err}
+	if len(c.ring) < c.size {
+		c.ring = append(c.ring, entry)
+	} else {
+		for atomic.LoadUint32(&c.ring[c.hand].used) == 1 {
+			atomic.StoreUint32(&c.ring[c.hand].used, 0)
+			c.hand = (c.hand + 1) % c.size
+		}
+
+		c.entries.Delete(c.ring[c.hand].expr)
+		c.ring[c.hand] = entry
+		c.hand = (c.hand + 1) % c.size
+	}
+
+	c.entries.Store(expr, entry)
+	return re, err
+}
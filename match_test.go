@@ -0,0 +1,130 @@
+package env_test
+
+import (
+	"errors"
+	"os"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/gomodrepo/env"
+)
+
+func TestMatcher(t *testing.T) {
+	os.Setenv(_testKey, _testValue)
+	defer os.Unsetenv(_testKey)
+
+	scenarios := []struct {
+		desc      string
+		get       func() string
+		wantValue string
+	}{
+		{
+			desc:      "#00",
+			get:       func() string { return env.GetInMatcher(_testKey, _defaultValue, env.MustCompile("^test")) },
+			wantValue: _testValue,
+		},
+		{
+			desc:      "#01",
+			get:       func() string { return env.GetInMatcher(_testKey, _defaultValue, regexp.MustCompile("^x")) },
+			wantValue: _defaultValue,
+		},
+		{
+			desc:      "#02",
+			get:       func() string { return env.GetExceptMatcher(_testKey, _defaultValue, env.MustCompile("Value$")) },
+			wantValue: _defaultValue,
+		},
+		{
+			desc:      "#03",
+			get:       func() string { return env.GetExceptMatcher(_testKey, _defaultValue, env.MustCompile("^x")) },
+			wantValue: _testValue,
+		},
+		{
+			desc:      "#04",
+			get:       func() string { return env.MustGetInMatcher(_testKey, env.MustCompile("^x"), env.MustCompile("^t")) },
+			wantValue: _testValue,
+		},
+		{
+			desc:      "#05",
+			get:       func() string { return env.MustGetExceptMatcher(_testKey, env.MustCompile("^x")) },
+			wantValue: _testValue,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run("Matcher", func(t *testing.T) {
+			if got := s.get(); got != s.wantValue {
+				t.Errorf("%v: got '%v' want '%v'", s.desc, got, s.wantValue)
+			}
+		})
+	}
+
+	_, err := env.LookupInMatcher(_testKey, env.MustCompile("^x"))
+	var e *env.Error
+	if !errors.As(err, &e) || e.Constraint != `regex ["^x"]` {
+		t.Errorf("got error '%v'", err)
+	}
+
+	if _, err := env.Compile("("); !errors.Is(err, env.ErrBadPattern) {
+		t.Errorf("got error '%v' want '%v'", err, env.ErrBadPattern)
+	}
+}
+
+func TestRegexCache(t *testing.T) {
+	os.Setenv(_testKey, _testValue)
+	defer os.Unsetenv(_testKey)
+
+	env.GetInRegex(_testKey, _defaultValue, "^cache-warm-up", "Value$")
+
+	before := env.Compilations()
+	for i := 0; i < 100; i++ {
+		env.GetInRegex(_testKey, _defaultValue, "^cache-warm-up", "Value$")
+		env.LookupExceptRegex(_testKey, "^cache-warm-up")
+	}
+
+	if got := env.Compilations() - before; got != 0 {
+		t.Errorf("got %v compilations after warm-up want 0", got)
+	}
+}
+
+func BenchmarkGetInRegex(b *testing.B) {
+	os.Setenv(_testKey, _testValue)
+	defer os.Unsetenv(_testKey)
+
+	env.GetInRegex(_testKey, _defaultValue, "^t([a-zA-Z]+)e$")
+	before := env.Compilations()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		env.GetInRegex(_testKey, _defaultValue, "^t([a-zA-Z]+)e$")
+	}
+
+	b.ReportMetric(float64(env.Compilations()-before), "compilations")
+}
+
+func BenchmarkGetInMatcher(b *testing.B) {
+	os.Setenv(_testKey, _testValue)
+	defer os.Unsetenv(_testKey)
+
+	p := env.MustCompile("^t([a-zA-Z]+)e$")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		env.GetInMatcher(_testKey, _defaultValue, p)
+	}
+}
+
+func TestRegexCacheBound(t *testing.T) {
+	os.Setenv(_testKey, _testValue)
+	defer os.Unsetenv(_testKey)
+
+	for i := 0; i < 1000; i++ {
+		env.GetInRegex(_testKey, _defaultValue, "^bound-"+strconv.Itoa(i))
+	}
+
+	if got := env.RegexCacheLen(); got > 256 {
+		t.Errorf("got %v cached expressions want at most 256", got)
+	}
+}
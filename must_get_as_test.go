@@ -0,0 +1,121 @@
+package env_test
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gomodrepo/env"
+)
+
+func TestMustGetAs(t *testing.T) {
+	scenarios := []struct {
+		desc      string
+		setKey    string
+		setValue  string
+		get       func() any
+		want      any
+		wantPanic bool
+	}{
+		{
+			desc:      "#00",
+			get:       func() any { return env.MustGetInt(_testKey) },
+			wantPanic: true,
+		},
+		{
+			desc:     "#01",
+			setKey:   _testKey,
+			setValue: "9090",
+			get:      func() any { return env.MustGetInt(_testKey) },
+			want:     9090,
+		},
+		{
+			desc:      "#02",
+			setKey:    _testKey,
+			setValue:  "port",
+			get:       func() any { return env.MustGetInt(_testKey) },
+			wantPanic: true,
+		},
+		{
+			desc:     "#03",
+			setKey:   _testKey,
+			setValue: "7",
+			get:      func() any { return env.MustGetUint(_testKey) },
+			want:     uint(7),
+		},
+		{
+			desc:     "#04",
+			setKey:   _testKey,
+			setValue: "0",
+			get:      func() any { return env.MustGetBool(_testKey) },
+			want:     false,
+		},
+		{
+			desc:     "#05",
+			setKey:   _testKey,
+			setValue: "2.5",
+			get:      func() any { return env.MustGetFloat64(_testKey) },
+			want:     2.5,
+		},
+		{
+			desc:     "#06",
+			setKey:   _testKey,
+			setValue: "250ms",
+			get:      func() any { return env.MustGetDuration(_testKey) },
+			want:     250 * time.Millisecond,
+		},
+		{
+			desc:     "#07",
+			setKey:   _testKey,
+			setValue: "2",
+			get:      func() any { return env.MustGetAsIn(_testKey, 1, 2) },
+			want:     2,
+		},
+		{
+			desc:      "#08",
+			setKey:    _testKey,
+			setValue:  "3",
+			get:       func() any { return env.MustGetAsIn(_testKey, 1, 2) },
+			wantPanic: true,
+		},
+		{
+			desc:      "#09",
+			setKey:    _testKey,
+			setValue:  "2",
+			get:       func() any { return env.MustGetAsExcept(_testKey, 1, 2) },
+			wantPanic: true,
+		},
+		{
+			desc:     "#10",
+			setKey:   _testKey,
+			setValue: "3",
+			get:      func() any { return env.MustGetAsExcept(_testKey, 1, 2) },
+			want:     3,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run("MustGetAs", func(t *testing.T) {
+			backup, ok := os.LookupEnv(s.setKey)
+			defer func() {
+				if ok {
+					os.Setenv(s.setKey, backup)
+				} else {
+					os.Unsetenv(s.setKey)
+				}
+
+				p := recover()
+				if (p == nil && s.wantPanic) || (p != nil && !s.wantPanic) {
+					t.Errorf("%v: gotPanic '%v' wantPanic '%v'", s.desc, p, s.wantPanic)
+				}
+			}()
+
+			os.Setenv(s.setKey, s.setValue)
+
+			got := s.get()
+			if got != s.want {
+				t.Errorf("%v: got '%v' want '%v'", s.desc, got, s.want)
+			}
+		})
+	}
+}
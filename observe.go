@@ -0,0 +1,200 @@
+package env
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"sync"
+	"text/tabwriter"
+)
+
+// Origins of the value of a variable, as reported by Result and Event.
+const (
+	// OriginSource is the origin of a value read from the Source of the Env.
+	OriginSource = "source"
+	// OriginFile is the origin of a value read from the file named by a _FILE variable.
+	OriginFile = "file"
+	// OriginDefault is the origin of a default value.
+	OriginDefault = "default"
+)
+
+// redacted replaces the values of secret variables.
+const redacted = "(hidden)"
+
+// Event describes a read of a variable.
+type Event struct {
+	Key string
+	// Value is the value returned, "(hidden)" if the variable is secret.
+	Value string
+	// Set reports whether the variable is set in the source.
+	Set bool
+	// Defaulted reports whether Value is the default value.
+	Defaulted bool
+	// Origin is where Value comes from, as in Result.
+	Origin string
+	// Secret reports whether the variable is secret.
+	Secret bool
+	// Constraint describes the constraint that rejected the value, if any.
+	Constraint string
+	// Err is the reason the value was not used, as in Result, with secret values redacted.
+	Err error
+}
+
+func (ev Event) String() string {
+	var s string
+	switch {
+	case ev.Origin != "":
+		s = fmt.Sprintf("%s=%q (%s)", ev.Key, ev.Value, ev.Origin)
+	case ev.Set:
+		s = ev.Key + " (rejected)"
+	default:
+		s = ev.Key + " (not set)"
+	}
+
+	if ev.Err != nil {
+		s += ": " + ev.Err.Error()
+	}
+
+	return s
+}
+
+// Observer is notified of every read of a variable through an Env.
+// Observers must be safe for concurrent use.
+type Observer interface {
+	Observe(ev Event)
+}
+
+// ObserverFunc adapts a function to the Observer interface.
+type ObserverFunc func(ev Event)
+
+// Observe calls f(ev).
+func (f ObserverFunc) Observe(ev Event) {
+	f(ev)
+}
+
+// WithObserver notifies 'o' of every read of a variable through an Env,
+// in addition to the observers already set.
+func WithObserver(o Observer) Option {
+	return func(e *Env) {
+		e.observers = append(e.observers[:len(e.observers):len(e.observers)], o)
+	}
+}
+
+// LogObserver returns an Observer printing every read to 'l', or to the standard logger if 'l' is nil.
+func LogObserver(l *log.Logger) Observer {
+	if l == nil {
+		l = log.Default()
+	}
+
+	return ObserverFunc(func(ev Event) {
+		l.Print("env: read " + ev.String())
+	})
+}
+
+// AuditLog is an Observer keeping every read in memory, e.g. to dump them at shutdown.
+// An AuditLog is safe for concurrent use.
+type AuditLog struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+// Observe records 'ev'.
+func (a *AuditLog) Observe(ev Event) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.events = append(a.events, ev)
+}
+
+// Events returns the recorded reads in order.
+func (a *AuditLog) Events() []Event {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return append([]Event(nil), a.events...)
+}
+
+// Dump writes the recorded reads to 'w' as a human-readable table.
+func (a *AuditLog) Dump(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "KEY\tVALUE\tORIGIN\tDEFAULTED\tERROR")
+
+	for _, ev := range a.Events() {
+		errMsg := ""
+		if ev.Err != nil {
+			errMsg = ev.Err.Error()
+		}
+
+		fmt.Fprintf(tw, "%s\t%q\t%s\t%t\t%s\n", ev.Key, ev.Value, ev.Origin, ev.Defaulted, errMsg)
+	}
+
+	return tw.Flush()
+}
+
+// notify reports the evaluation of 'v' to the registry and the observers of 'e'.
+func (e *Env) notify(v *Variable, r Result) {
+	if len(e.observers) == 0 && e.registry == nil {
+		return
+	}
+
+	ev := Event{
+		Key:       v.key,
+		Value:     r.Value,
+		Set:       r.Set,
+		Defaulted: r.Defaulted,
+		Origin:    r.Origin,
+		Secret:    v.secret,
+		Err:       r.Err,
+	}
+
+	if r.Err != nil {
+		var err *Error
+		if errors.As(r.Err, &err) && err.Err != ErrNotSet {
+			ev.Constraint = err.Constraint
+		}
+	}
+
+	if !ev.Secret {
+		_, ev.Secret = e.secrets.Load(v.key)
+	}
+
+	if ev.Secret {
+		ev.redact()
+	}
+
+	if e.registry != nil {
+		e.registry.record(&ev)
+	}
+
+	for _, o := range e.observers {
+		o.Observe(ev)
+	}
+}
+
+// redact hides the value of the event.
+func (ev *Event) redact() {
+	ev.Secret = true
+	if ev.Value != "" {
+		ev.Value = redacted
+	}
+
+	ev.Err = redact(ev.Err)
+}
+
+// redact returns 'err' with the offending value hidden.
+func redact(err error) error {
+	switch err := err.(type) {
+	case *Error:
+		c := *err
+		if c.Value != "" {
+			c.Value = redacted
+		}
+
+		return &c
+	case *ElementError:
+		return &ElementError{Index: err.Index, Err: redact(err.Err).(*Error)}
+	}
+
+	return err
+}
@@ -0,0 +1,139 @@
+package env_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/gomodrepo/env"
+)
+
+func TestObserver(t *testing.T) {
+	var audit env.AuditLog
+	var buf bytes.Buffer
+
+	e := env.New(env.Map{
+		"MODE":     "staging",
+		"PORT":     "80",
+		"TOKEN":    "s3cr3t",
+		"TIMEOUT":  "soon",
+		"ORIGINS":  "a.com,b.com",
+		"PASSWORD": "hunter2",
+	}, env.WithRegistry(env.NewRegistry()), env.WithObserver(&audit), env.WithObserver(env.LogObserver(log.New(&buf, "", 0))))
+
+	e.GetIn("MODE", "dev", "dev", "prod")
+	e.Get("HOST", "localhost")
+	e.GetInt("PORT", 8080)
+	e.GetDuration("TIMEOUT", 0)
+	_ = e.Var("TOKEN").Secret().OneOf("x").String()
+	e.GetList("ORIGINS", nil)
+	e.Var("PASSWORD").Secret().Declare()
+	e.Get("PASSWORD", "")
+	e.Lookup("MISSING")
+
+	want := []env.Event{
+		{Key: "MODE", Value: "dev", Set: true, Defaulted: true, Origin: env.OriginDefault, Constraint: `in ["dev" "prod"]`},
+		{Key: "HOST", Value: "localhost", Defaulted: true, Origin: env.OriginDefault},
+		{Key: "PORT", Value: "80", Set: true, Origin: env.OriginSource},
+		{Key: "TIMEOUT", Value: "0s", Set: true, Defaulted: true, Origin: env.OriginDefault, Constraint: "type time.Duration"},
+		{Key: "TOKEN", Value: "", Set: true, Secret: true, Constraint: `in ["x"]`},
+		{Key: "ORIGINS", Value: "a.com,b.com", Set: true, Origin: env.OriginSource},
+		{Key: "PASSWORD", Value: "(hidden)", Set: true, Secret: true, Origin: env.OriginSource},
+		{Key: "MISSING"},
+	}
+
+	got := audit.Events()
+	if len(got) != len(want) {
+		t.Fatalf("got %d events want %d: %v", len(got), len(want), got)
+	}
+
+	for i, ev := range got {
+		w := want[i]
+		if ev.Key != w.Key || ev.Value != w.Value || ev.Set != w.Set || ev.Defaulted != w.Defaulted ||
+			ev.Origin != w.Origin || ev.Secret != w.Secret || ev.Constraint != w.Constraint {
+			t.Errorf("#%02d: got '%+v' want '%+v'", i, ev, w)
+		}
+	}
+
+	if err := got[4].Err; err == nil || strings.Contains(err.Error(), "s3cr3t") {
+		t.Errorf("got '%v' want a redacted error", err)
+	}
+
+	if !errors.Is(got[7].Err, env.ErrNotSet) {
+		t.Errorf("got '%v' want '%v'", got[7].Err, env.ErrNotSet)
+	}
+
+	var dump strings.Builder
+	audit.Dump(&dump)
+
+	for _, out := range []string{dump.String(), buf.String()} {
+		if strings.Contains(out, "s3cr3t") || strings.Contains(out, "hunter2") {
+			t.Errorf("got secret in output\n%v", out)
+		}
+
+		if !strings.Contains(out, "MODE") {
+			t.Errorf("got output\n%v\nwant it to contain 'MODE'", out)
+		}
+	}
+}
+
+func TestObserverSecretWithoutRegistry(t *testing.T) {
+	var audit env.AuditLog
+	e := env.New(env.Map{"S": "hunter2"}, env.WithObserver(&audit))
+
+	e.Var("S").Secret()
+	e.LookupIn("S", "x")
+	e.With(env.WithEmptyAsUnset()).Get("S", "y")
+
+	got := audit.Events()
+	if len(got) != 2 {
+		t.Fatalf("got %v events want 2", len(got))
+	}
+
+	for _, ev := range got {
+		if !ev.Secret || ev.Value == "hunter2" || ev.Err != nil && strings.Contains(ev.Err.Error(), "hunter2") {
+			t.Errorf("got '%+v' want a redacted event", ev)
+		}
+	}
+
+	var dump strings.Builder
+	audit.Dump(&dump)
+
+	if strings.Contains(dump.String(), "hunter2") {
+		t.Errorf("got secret in output\n%v", dump.String())
+	}
+}
+
+func TestSecretErrors(t *testing.T) {
+	e := env.New(env.Map{"PW": "hunter2", "TOKEN": "s3cr3t"})
+	e.Var("TOKEN").Secret()
+
+	_, err := e.Var("PW").Secret().Match("^x").Lookup()
+	if err == nil || strings.Contains(err.Error(), "hunter2") {
+		t.Errorf("got '%v' want a redacted error", err)
+	}
+
+	c := e.NewChecker()
+	c.RequireIn("TOKEN", "a", "b")
+	c.Check(e.Var("PW").Secret().OneOf("a"))
+
+	var ve *env.ValidationError
+	if !errors.As(c.Err(), &ve) || len(ve.Errors) != 2 {
+		t.Fatalf("got '%v' want 2 errors", c.Err())
+	}
+
+	if table := ve.Table(); strings.Contains(table, "hunter2") || strings.Contains(table, "s3cr3t") {
+		t.Errorf("got secret in table\n%v", table)
+	}
+
+	defer func() {
+		if r := recover(); r == nil || strings.Contains(fmt.Sprint(r), "hunter2") {
+			t.Errorf("got panic '%v' want a redacted error", r)
+		}
+	}()
+
+	e.Var("PW").Secret().NonEmpty().Match("^x").MustString()
+}
@@ -0,0 +1,69 @@
+package env_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gomodrepo/env"
+)
+
+func TestWithPrefix(t *testing.T) {
+	e := env.New(env.Map{
+		"PAYMENTS_MODE":    "prod",
+		"PAYMENTS_DB_HOST": "db",
+		"PAYMENTS_DB_PORT": "5432",
+		"OTHER_MODE":       "dev",
+	})
+
+	p := e.WithPrefix("PAYMENTS_")
+	db := p.WithPrefix("DB_")
+
+	scenarios := []struct {
+		desc string
+		got  any
+		want any
+	}{
+		{desc: "#00", got: p.Get("MODE", "dev"), want: "prod"},
+		{desc: "#01", got: p.GetIn("MODE", "dev", "dev"), want: "dev"},
+		{desc: "#02", got: p.GetExcept("MODE", "dev", "test"), want: "prod"},
+		{desc: "#03", got: db.MustGet("HOST"), want: "db"},
+		{desc: "#04", got: db.GetInt("PORT", 0), want: 5432},
+		{desc: "#05", got: db.Prefix(), want: "PAYMENTS_DB_"},
+		{desc: "#06", got: db.Keys(), want: []string{"HOST", "PORT"}},
+		{desc: "#07", got: p.Var("MODE").OneOf("prod").String(), want: "prod"},
+		{desc: "#08", got: e.Get("MODE", "none"), want: "none"},
+	}
+
+	for _, s := range scenarios {
+		t.Run("WithPrefix", func(t *testing.T) {
+			if !reflect.DeepEqual(s.got, s.want) {
+				t.Errorf("%v: got '%v' want '%v'", s.desc, s.got, s.want)
+			}
+		})
+	}
+
+	var ee *env.Error
+	if _, err := db.LookupIn("HOST", "localhost"); !errors.As(err, &ee) || ee.Key != "PAYMENTS_DB_HOST" {
+		t.Errorf("got error '%v' want key 'PAYMENTS_DB_HOST'", err)
+	}
+	if _, err := env.As[int](db).Lookup("HOST"); !errors.As(err, &ee) || ee.Key != "PAYMENTS_DB_HOST" {
+		t.Errorf("got error '%v' want key 'PAYMENTS_DB_HOST'", err)
+	}
+
+	var c struct {
+		User string `env:"USER" required:"true"`
+	}
+	if err := db.Bind(&c); err == nil || !strings.Contains(err.Error(), "PAYMENTS_DB_USER") {
+		t.Errorf("got error '%v' want key 'PAYMENTS_DB_USER'", err)
+	}
+
+	defer func() {
+		if p := recover(); p == nil || !strings.Contains(p.(error).Error(), "PAYMENTS_MISSING") {
+			t.Errorf("got panic '%v' want key 'PAYMENTS_MISSING'", p)
+		}
+	}()
+
+	p.MustGet("MISSING")
+}
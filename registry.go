@@ -0,0 +1,324 @@
+package env
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+)
+
+// Decl describes a variable declared through the env API.
+type Decl struct {
+	Key         string
+	Description string
+	// Default is the default value, meaningful only if HasDefault is set.
+	Default    string
+	HasDefault bool
+	// Required is set when the variable has no default value.
+	Required bool
+	// Secret is set when the value must not be displayed.
+	Secret bool
+	// In and Except are the allowed and excluded values, as in GetIn and GetExcept.
+	In, Except []string
+	// Match and NotMatch are the allowed and excluded patterns, as in GetInRegex and GetExceptRegex.
+	Match, NotMatch []string
+	// Glob and NotGlob are the allowed and excluded glob patterns, as in GetInGlob and GetExceptGlob.
+	Glob, NotGlob []string
+	// Range holds the numeric bounds of the value, as in GetAsWithin.
+	Range []string
+	// CaseInsensitive is set when In, Except, Glob and NotGlob are not case sensitive.
+	CaseInsensitive bool
+	// NonEmpty is set when the value must not be empty.
+	NonEmpty bool
+}
+
+// constraints describes the constraints of the declaration.
+func (d Decl) constraints() []string {
+	var c []string
+
+	suffix := ""
+	if d.CaseInsensitive {
+		suffix = " (case insensitive)"
+	}
+
+	if len(d.In) > 0 {
+		c = append(c, "one of: "+strings.Join(d.In, ", ")+suffix)
+	}
+
+	if len(d.Except) > 0 {
+		c = append(c, "not one of: "+strings.Join(d.Except, ", ")+suffix)
+	}
+
+	if d.NonEmpty {
+		c = append(c, "non-empty")
+	}
+
+	if len(d.Match) > 0 {
+		c = append(c, "matching: "+strings.Join(d.Match, ", "))
+	}
+
+	if len(d.NotMatch) > 0 {
+		c = append(c, "not matching: "+strings.Join(d.NotMatch, ", "))
+	}
+
+	if len(d.Range) > 0 {
+		c = append(c, "range: "+strings.Join(d.Range, ", "))
+	}
+
+	if len(d.Glob) > 0 {
+		c = append(c, "matching glob: "+strings.Join(d.Glob, ", ")+suffix)
+	}
+
+	if len(d.NotGlob) > 0 {
+		c = append(c, "not matching glob: "+strings.Join(d.NotGlob, ", ")+suffix)
+	}
+
+	return c
+}
+
+// defaultString returns the default value for display, hiding secrets.
+func (d Decl) defaultString() string {
+	switch {
+	case !d.HasDefault:
+		return ""
+	case d.Secret:
+		return "(hidden)"
+	}
+
+	return fmt.Sprintf("%q", d.Default)
+}
+
+// Registry records the variables declared through the env API,
+// and renders them as documentation.
+// A Registry is safe for concurrent use.
+type Registry struct {
+	mu    sync.RWMutex
+	decls map[string]Decl
+	// reads holds the last read of every variable.
+	reads map[string]Event
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{decls: make(map[string]Decl), reads: make(map[string]Event)}
+}
+
+// DefaultRegistry records the variables read by the package-level functions.
+var DefaultRegistry = NewRegistry()
+
+// WithRegistry records the variables read through an Env in 'r'.
+func WithRegistry(r *Registry) Option {
+	return func(e *Env) {
+		e.registry = r
+	}
+}
+
+// Declare records 'd', merging it with a previous declaration of the same key:
+// fields left empty in 'd' keep their previous value, Secret and NonEmpty are
+// combined and the variable stays required only if both declarations require it.
+func (r *Registry) Declare(d Decl) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if old, ok := r.decls[d.Key]; ok {
+		d = merge(old, d)
+	}
+
+	r.decls[d.Key] = d
+}
+
+// merge returns 'd' completed with the fields of 'old' it leaves empty.
+func merge(old, d Decl) Decl {
+	if d.Description == "" {
+		d.Description = old.Description
+	}
+
+	if !d.HasDefault {
+		d.Default, d.HasDefault = old.Default, old.HasDefault
+	}
+
+	d.Required = d.Required && old.Required && !d.HasDefault
+	d.Secret = d.Secret || old.Secret
+	if len(d.In)+len(d.Except)+len(d.Glob)+len(d.NotGlob) == 0 {
+		d.CaseInsensitive = old.CaseInsensitive
+	}
+
+	d.NonEmpty = d.NonEmpty || old.NonEmpty
+
+	for _, f := range []struct{ dst, old *[]string }{
+		{&d.In, &old.In},
+		{&d.Except, &old.Except},
+		{&d.Match, &old.Match},
+		{&d.NotMatch, &old.NotMatch},
+		{&d.Range, &old.Range},
+		{&d.Glob, &old.Glob},
+		{&d.NotGlob, &old.NotGlob},
+	} {
+		if len(*f.dst) == 0 {
+			*f.dst = *f.old
+		}
+	}
+
+	return d
+}
+
+// Decls returns the recorded declarations sorted by key.
+func (r *Registry) Decls() []Decl {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	decls := make([]Decl, 0, len(r.decls))
+	for _, d := range r.decls {
+		decls = append(decls, d)
+	}
+
+	sort.Slice(decls, func(i, j int) bool { return decls[i].Key < decls[j].Key })
+	return decls
+}
+
+// Lookup returns the declaration of 'key' and whether it is recorded.
+func (r *Registry) Lookup(key string) (Decl, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	d, ok := r.decls[key]
+	return d, ok
+}
+
+// LastRead returns the last read of 'key' and whether it was read.
+func (r *Registry) LastRead(key string) (Event, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ev, ok := r.reads[key]
+	return ev, ok
+}
+
+// record stores 'ev' as the last read of its key, redacting it first if the key is declared secret.
+// A read without error equal to the stored one is not stored again, so that repeated reads
+// only take the read lock.
+func (r *Registry) record(ev *Event) {
+	r.mu.RLock()
+	old, ok := r.reads[ev.Key]
+	secret := r.decls[ev.Key].Secret
+	r.mu.RUnlock()
+
+	if secret && !ev.Secret {
+		ev.redact()
+	}
+
+	// Errors are compared only when both are nil, as they may not be comparable.
+	if ok && old.Err == nil && ev.Err == nil && old == *ev {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.reads[ev.Key] = *ev
+}
+
+// WriteUsage writes the declarations to 'w' as a usage block suitable for --help.
+func (r *Registry) WriteUsage(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "Environment variables:")
+
+	for _, d := range r.Decls() {
+		details := d.constraints()
+		if d.HasDefault {
+			details = append(details, "default: "+d.defaultString())
+		}
+
+		if d.Required {
+			details = append(details, "required")
+		}
+
+		if d.Secret {
+			details = append(details, "secret")
+		}
+
+		line := d.Description
+		if len(details) > 0 {
+			line = strings.TrimSpace(line + " (" + strings.Join(details, "; ") + ")")
+		}
+
+		fmt.Fprintf(tw, "  %s\t%s\n", d.Key, line)
+	}
+
+	return tw.Flush()
+}
+
+// WriteMarkdown writes the declarations to 'w' as a Markdown table.
+func (r *Registry) WriteMarkdown(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("| Variable | Description | Default | Required | Constraints |\n")
+	b.WriteString("| --- | --- | --- | --- | --- |\n")
+
+	for _, d := range r.Decls() {
+		required := "no"
+		if d.Required {
+			required = "yes"
+		}
+
+		def := d.defaultString()
+		if def != "" {
+			def = "`" + def + "`"
+		}
+
+		fmt.Fprintf(&b, "| `%s` | %s | %s | %s | %s |\n",
+			d.Key,
+			markdownEscape(d.Description),
+			markdownEscape(def),
+			required,
+			markdownEscape(strings.Join(d.constraints(), "; ")),
+		)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteMan writes the declarations to 'w' as an ENVIRONMENT section of a roff man page.
+func (r *Registry) WriteMan(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString(".SH ENVIRONMENT\n")
+
+	for _, d := range r.Decls() {
+		b.WriteString(".TP\n.B " + roffEscape(d.Key) + "\n")
+
+		if d.Description != "" {
+			b.WriteString(roffEscape(d.Description) + "\n")
+		}
+
+		for _, c := range d.constraints() {
+			b.WriteString(".br\n" + roffEscape(strings.ToUpper(c[:1])+c[1:]) + ".\n")
+		}
+
+		if d.HasDefault {
+			b.WriteString(".br\nDefault: " + roffEscape(d.defaultString()) + ".\n")
+		}
+
+		if d.Required {
+			b.WriteString(".br\nRequired.\n")
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func markdownEscape(s string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(s)
+}
+
+func roffEscape(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\e`)
+	s = strings.ReplaceAll(s, "\n", " ")
+	if strings.HasPrefix(s, ".") || strings.HasPrefix(s, "'") {
+		s = `\&` + s
+	}
+
+	return s
+}
@@ -0,0 +1,114 @@
+package env_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gomodrepo/env"
+)
+
+func TestRegistry(t *testing.T) {
+	r := env.NewRegistry()
+	e := env.New(env.Map{"MODE": "prod"}, env.WithRegistry(r))
+
+	_ = e.Var("MODE").Describe("Run mode.").Default("dev").OneOf("dev", "prod").CaseInsensitive().String()
+	e.Var("TOKEN").Describe("API token | v2.").Default("xyz").Secret().NotMatch(`^test`).Declare()
+	e.LookupInRegex("REGION", `^eu-`)
+	e.GetExcept("MODE", "dev", "test")
+
+	var c struct {
+		Host string `env:"DB_HOST" default:"localhost" desc:".local host"`
+	}
+	e.Bind(&c)
+
+	decls := r.Decls()
+	if len(decls) != 4 {
+		t.Fatalf("got %v declarations want 4", len(decls))
+	}
+
+	mode, ok := r.Lookup("MODE")
+	if !ok || mode.Description != "Run mode." || len(mode.In) != 2 || len(mode.Except) != 1 {
+		t.Errorf("got '%+v' want merged declaration", mode)
+	}
+
+	scenarios := []struct {
+		desc  string
+		write func(b *strings.Builder) error
+		want  string
+	}{
+		{
+			desc:  "#00",
+			write: func(b *strings.Builder) error { return r.WriteUsage(b) },
+			want: "Environment variables:\n" +
+				"  DB_HOST  .local host (default: \"localhost\")\n" +
+				"  MODE     Run mode. (one of: dev, prod; not one of: test; default: \"dev\")\n" +
+				"  REGION   (matching: ^eu-; required)\n" +
+				"  TOKEN    API token | v2. (not matching: ^test; default: (hidden); secret)\n",
+		},
+		{
+			desc:  "#01",
+			write: func(b *strings.Builder) error { return r.WriteMarkdown(b) },
+			want: "| Variable | Description | Default | Required | Constraints |\n" +
+				"| --- | --- | --- | --- | --- |\n" +
+				"| `DB_HOST` | .local host | `\"localhost\"` | no |  |\n" +
+				"| `MODE` | Run mode. | `\"dev\"` | no | one of: dev, prod; not one of: test |\n" +
+				"| `REGION` |  |  | yes | matching: ^eu- |\n" +
+				"| `TOKEN` | API token \\| v2. | `(hidden)` | no | not matching: ^test |\n",
+		},
+		{
+			desc:  "#02",
+			write: func(b *strings.Builder) error { return r.WriteMan(b) },
+			want: ".SH ENVIRONMENT\n" +
+				".TP\n.B DB_HOST\n\\&.local host\n.br\nDefault: \"localhost\".\n" +
+				".TP\n.B MODE\nRun mode.\n.br\nOne of: dev, prod.\n.br\nNot one of: test.\n.br\nDefault: \"dev\".\n" +
+				".TP\n.B REGION\n.br\nMatching: ^eu-.\n.br\nRequired.\n" +
+				".TP\n.B TOKEN\nAPI token | v2.\n.br\nNot matching: ^test.\n.br\nDefault: (hidden).\n",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run("Registry", func(t *testing.T) {
+			var b strings.Builder
+			if err := s.write(&b); err != nil || b.String() != s.want {
+				t.Errorf("%v: got\n%v\nwant\n%v", s.desc, b.String(), s.want)
+			}
+		})
+	}
+}
+
+func TestRegistryMerge(t *testing.T) {
+	r := env.NewRegistry()
+	e := env.New(env.Map{"X": "b"}, env.WithRegistry(r))
+
+	e.Var("X").Describe("Declared first.").OneOf("a", "b").Declare()
+	e.Get("X", "a")
+
+	x, _ := r.Lookup("X")
+	if x.Description != "Declared first." || len(x.In) != 2 || !x.HasDefault || x.Default != "a" || x.Required {
+		t.Errorf("got '%+v' want the constraints of the first declaration and the default of the read", x)
+	}
+}
+
+func TestRegistryRedeclare(t *testing.T) {
+	r := env.NewRegistry()
+	e := env.New(env.Map{"M": "c"}, env.WithRegistry(r))
+
+	e.GetIn("M", "a", "a", "b")
+	e.GetIn("M", "a", "c")
+	e.GetIn("M", "a", "c")
+
+	var b strings.Builder
+	r.WriteUsage(&b)
+
+	if want := "Environment variables:\n  M  (one of: c; default: \"a\")\n"; b.String() != want {
+		t.Errorf("got\n%v\nwant\n%v", b.String(), want)
+	}
+}
+
+func TestDefaultRegistry(t *testing.T) {
+	env.Var("TEST_REGISTRY_KEY").Describe("Recorded by the package-level functions.").Declare()
+
+	if _, ok := env.DefaultRegistry.Lookup("TEST_REGISTRY_KEY"); !ok {
+		t.Errorf("got no declaration for 'TEST_REGISTRY_KEY'")
+	}
+}
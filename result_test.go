@@ -0,0 +1,91 @@
+package env_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gomodrepo/env"
+)
+
+func TestResult(t *testing.T) {
+	e := env.New(env.Map{
+		"MODE":    "prod",
+		"EXCLUDE": "x",
+	})
+
+	scenarios := []struct {
+		desc          string
+		v             *env.Variable
+		wantValue     string
+		wantSet       bool
+		wantDefaulted bool
+		wantErr       error
+	}{
+		{
+			desc:          "#00",
+			v:             e.Var(_testKey).Default("dev"),
+			wantValue:     "dev",
+			wantDefaulted: true,
+		},
+		{
+			desc:    "#01",
+			v:       e.Var(_testKey),
+			wantErr: env.ErrNotSet,
+		},
+		{
+			desc:      "#02",
+			v:         e.Var("MODE").Default("dev").OneOf("dev", "prod"),
+			wantValue: "prod",
+			wantSet:   true,
+		},
+		{
+			desc:      "#03",
+			v:         e.Var("MODE").Default("prod").OneOf("prod"),
+			wantValue: "prod",
+			wantSet:   true,
+		},
+		{
+			desc:          "#04",
+			v:             e.Var("EXCLUDE").Default("x").NotOneOf("x"),
+			wantValue:     "x",
+			wantSet:       true,
+			wantDefaulted: true,
+			wantErr:       env.ErrExcluded,
+		},
+		{
+			desc:    "#05",
+			v:       e.Var("EXCLUDE").NotOneOf("x"),
+			wantSet: true,
+			wantErr: env.ErrExcluded,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run("Result", func(t *testing.T) {
+			r := s.v.Result()
+			if r.Value != s.wantValue || r.Set != s.wantSet || r.Defaulted != s.wantDefaulted {
+				t.Errorf("%v: got '%+v' want value '%v', set '%v', defaulted '%v'", s.desc, r, s.wantValue, s.wantSet, s.wantDefaulted)
+			}
+
+			if !errors.Is(r.Err, s.wantErr) || (s.wantErr == nil && r.Err != nil) {
+				t.Errorf("%v: got error '%v' want '%v'", s.desc, r.Err, s.wantErr)
+			}
+		})
+	}
+}
+
+func TestExceptDefault(t *testing.T) {
+	e := env.New(env.Map{_testKey: "x"})
+
+	if _, err := e.LookupExcept(_testKey, "x"); !errors.Is(err, env.ErrExcluded) {
+		t.Errorf("got '%v' want '%v'", err, env.ErrExcluded)
+	}
+
+	if got := env.As[int](env.New(env.Map{_testKey: "3"})).GetIn(_testKey, 3, 1, 2); got != 3 {
+		t.Errorf("got '%v' want '%v'", got, 3)
+	}
+
+	if got := env.As[int](env.New(env.Map{_testKey: "2"})).GetExcept(_testKey, 1, 2); got != 1 {
+		t.Errorf("got '%v' want '%v'", got, 1)
+	}
+}
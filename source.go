@@ -0,0 +1,61 @@
+package env
+
+import (
+	"os"
+	"sort"
+	"strings"
+)
+
+// Source provides the variables read by an Env.
+type Source interface {
+	// Lookup returns the value of 'key' and whether it is set.
+	Lookup(key string) (string, bool)
+}
+
+// Keyer is implemented by sources that can list the keys they hold.
+type Keyer interface {
+	// Keys returns every key set in the source.
+	Keys() []string
+}
+
+// OS is the Source backed by the process environment.
+var OS Source = osSource{}
+
+type osSource struct{}
+
+func (osSource) Lookup(key string) (string, bool) {
+	return os.LookupEnv(key)
+}
+
+func (osSource) Keys() []string {
+	environ := os.Environ()
+
+	keys := make([]string, 0, len(environ))
+	for _, kv := range environ {
+		if i := strings.IndexByte(kv, '='); i > 0 {
+			keys = append(keys, kv[:i])
+		}
+	}
+
+	return keys
+}
+
+// Map is a Source backed by a map.
+type Map map[string]string
+
+// Lookup returns the value of 'key' and whether it is set.
+func (m Map) Lookup(key string) (string, bool) {
+	value, ok := m[key]
+	return value, ok
+}
+
+// Keys returns the keys of the map in sorted order.
+func (m Map) Keys() []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,62 @@
+package env_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/gomodrepo/env"
+)
+
+func TestEnv(t *testing.T) {
+	e := env.New(env.Map{
+		"MODE":    "prod",
+		"PORT":    "8080",
+		"REGION":  "eu-1",
+		"TIMEOUT": "5s",
+	})
+
+	scenarios := []struct {
+		desc string
+		got  any
+		want any
+	}{
+		{desc: "#00", got: e.Get("MODE", "dev"), want: "prod"},
+		{desc: "#01", got: e.Get(_testKey, "dev"), want: "dev"},
+		{desc: "#02", got: e.GetIn("MODE", "dev", "dev", "prod"), want: "prod"},
+		{desc: "#03", got: e.GetInCaseInsensitive("MODE", "dev", "PROD"), want: "prod"},
+		{desc: "#04", got: e.GetExcept("MODE", "dev", "prod"), want: "dev"},
+		{desc: "#05", got: e.GetInRegex("REGION", "us-1", "^eu-"), want: "eu-1"},
+		{desc: "#06", got: e.GetExceptRegex("REGION", "us-1", "^eu-"), want: "us-1"},
+		{desc: "#07", got: e.GetInt("PORT", 80), want: 8080},
+		{desc: "#08", got: e.MustGetDuration("TIMEOUT").String(), want: "5s"},
+		{desc: "#09", got: env.As[uint16](e).GetIn("PORT", 80, 8080), want: uint16(8080)},
+		{desc: "#10", got: e.MustGetInCaseInsensitive("MODE", "Prod"), want: "prod"},
+		{desc: "#11", got: e.Keys(), want: []string{"MODE", "PORT", "REGION", "TIMEOUT"}},
+	}
+
+	for _, s := range scenarios {
+		t.Run("Env", func(t *testing.T) {
+			if !reflect.DeepEqual(s.got, s.want) {
+				t.Errorf("%v: got '%v' want '%v'", s.desc, s.got, s.want)
+			}
+		})
+	}
+
+	if _, err := e.LookupExcept("MODE", "prod"); !errors.Is(err, env.ErrExcluded) {
+		t.Errorf("got error '%v' want '%v'", err, env.ErrExcluded)
+	}
+
+	var c struct {
+		Port int `env:"PORT"`
+	}
+	if err := e.Bind(&c); err != nil || c.Port != 8080 {
+		t.Errorf("got port '%v' error '%v' want '8080'", c.Port, err)
+	}
+}
+
+func TestDefault(t *testing.T) {
+	if env.Default().Source() != env.OS {
+		t.Errorf("got source '%v' want '%v'", env.Default().Source(), env.OS)
+	}
+}
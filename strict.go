@@ -0,0 +1,77 @@
+package env
+
+import (
+	"sort"
+	"strings"
+)
+
+// Unknown returns the keys set in the source of 'e' and starting with its prefix
+// that were never declared in its registry, either explicitly or by being read,
+// bound or checked. Keys are returned in full and sorted.
+//
+// It returns nil if 'e' has no registry or its source does not implement Keyer.
+// Call it once the application has read its configuration, e.g. to log warnings.
+func (e *Env) Unknown() []string {
+	if e.registry == nil {
+		return nil
+	}
+
+	var unknown []string
+	for _, k := range e.Keys() {
+		key := e.key(k)
+		if !e.declared(key) {
+			unknown = append(unknown, key)
+		}
+	}
+
+	sort.Strings(unknown)
+	return unknown
+}
+
+// CheckUnknown returns a *ValidationError wrapping ErrUnknown for every key
+// reported by Unknown, or nil if there is none.
+func (e *Env) CheckUnknown() error {
+	c := e.NewChecker()
+	c.RejectUnknown()
+
+	return c.Err()
+}
+
+// Unknown returns the variables of the environment that were never declared.
+// See Env.Unknown.
+func Unknown() []string {
+	return std.Unknown()
+}
+
+// CheckUnknown returns an error listing the variables of the environment that were never declared.
+// See Env.CheckUnknown.
+func CheckUnknown() error {
+	return std.CheckUnknown()
+}
+
+// declared reports whether 'key', or the variable it provides a file for, is in the registry of 'e'.
+func (e *Env) declared(key string) bool {
+	if _, ok := e.registry.Lookup(key); ok {
+		return true
+	}
+
+	if e.fileSuffix != "" && strings.HasSuffix(key, e.fileSuffix) {
+		_, ok := e.registry.Lookup(strings.TrimSuffix(key, e.fileSuffix))
+		return ok
+	}
+
+	return false
+}
+
+// unknown returns the error reporting that 'key' was never declared,
+// suggesting the closest declared key.
+func (e *Env) unknown(key string) *Error {
+	decls := e.registry.Decls()
+
+	keys := make([]string, len(decls))
+	for i, d := range decls {
+		keys[i] = d.Key
+	}
+
+	return &Error{Key: key, Suggestion: suggest(key, keys, true), Err: ErrUnknown}
+}
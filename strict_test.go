@@ -0,0 +1,58 @@
+package env_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/gomodrepo/env"
+)
+
+func TestUnknown(t *testing.T) {
+	src := env.Map{
+		"APP_MODE":          "prod",
+		"APP_TIMOUT":        "30s",
+		"APP_PASSWORD_FILE": "/run/secrets/password",
+		"APP_DB_HOST":       "db",
+		"OTHER":             "x",
+	}
+
+	e := env.New(src, env.WithRegistry(env.NewRegistry()), env.WithFiles("_FILE")).WithPrefix("APP_")
+
+	var cfg struct {
+		Timeout string `env:"TIMEOUT" default:"10s"`
+		DB      struct {
+			Host string `env:"HOST"`
+		} `env:"DB_"`
+	}
+
+	if err := e.Bind(&cfg); err != nil {
+		t.Fatalf("got '%v'", err)
+	}
+
+	_ = e.Var("MODE").Default("dev").String()
+	e.Var("PASSWORD").Secret().Declare()
+
+	want := []string{"APP_TIMOUT"}
+	if got := e.Unknown(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got '%v' want '%v'", got, want)
+	}
+
+	err := e.CheckUnknown()
+	if !errors.Is(err, env.ErrUnknown) {
+		t.Fatalf("got '%v' want '%v'", err, env.ErrUnknown)
+	}
+
+	if got, want := err.Error(), "env: unknown variable: APP_TIMOUT (did you mean APP_TIMEOUT?)"; got != want {
+		t.Errorf("got '%v' want '%v'", got, want)
+	}
+
+	e.Var("TIMOUT").Declare()
+	if err := e.CheckUnknown(); err != nil {
+		t.Errorf("got '%v' want nil", err)
+	}
+
+	if got := env.New(src).Unknown(); got != nil {
+		t.Errorf("got '%v' want nil", got)
+	}
+}
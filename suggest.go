@@ -0,0 +1,92 @@
+package env
+
+import "strings"
+
+// notSet returns the error reporting that 'key' is not set,
+// suggesting the closest key set in the source of 'e'.
+func (e *Env) notSet(key string) error {
+	err := &Error{Key: key, Err: ErrNotSet}
+
+	if k, ok := e.src.(Keyer); ok {
+		err.Suggestion = suggest(key, k.Keys(), true)
+	}
+
+	return err
+}
+
+// suggest returns the candidate closest to 'value' by edit distance,
+// or the empty string if none is close enough to be a likely typo.
+func suggest(value string, candidates []string, fold bool) string {
+	v := value
+	if fold {
+		v = strings.ToLower(v)
+	}
+
+	best, bestDist := "", -1
+	for _, c := range candidates {
+		s := c
+		if fold {
+			s = strings.ToLower(s)
+		}
+
+		if c == value {
+			continue
+		}
+
+		d := distance(v, s)
+		if d <= maxDistance(len(s)) && (bestDist < 0 || d < bestDist) {
+			best, bestDist = c, d
+		}
+	}
+
+	return best
+}
+
+// maxDistance returns the largest edit distance accepted for a suggestion of length 'n'.
+func maxDistance(n int) int {
+	return 1 + n/4
+}
+
+// distance returns the optimal string alignment distance between 'a' and 'b':
+// the number of insertions, deletions, substitutions and transpositions of
+// adjacent characters turning 'a' into 'b'.
+func distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	// d[i][j] is the distance between ra[:i] and rb[:j].
+	d := make([][]int, len(ra)+1)
+	for i := range d {
+		d[i] = make([]int, len(rb)+1)
+		d[i][0] = i
+	}
+
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			d[i][j] = minOf(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d[i][j] = minOf(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+
+	return d[len(ra)][len(rb)]
+}
+
+func minOf(n int, rest ...int) int {
+	for _, m := range rest {
+		if m < n {
+			n = m
+		}
+	}
+
+	return n
+}
@@ -0,0 +1,64 @@
+package env_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gomodrepo/env"
+)
+
+func TestSuggest(t *testing.T) {
+	e := env.New(env.Map{
+		"LOG_LEVEL":  "wraning",
+		"LOG_LEVLE":  "info",
+		"LOG_FORMAT": "xml",
+	})
+
+	scenarios := []struct {
+		desc    string
+		lookup  func() (string, error)
+		wantMsg string
+	}{
+		{
+			desc:    "#00",
+			lookup:  func() (string, error) { return e.LookupIn("LOG_LEVEL", "debug", "info", "warning") },
+			wantMsg: `env: value is not in: LOG_LEVEL: value "wraning", in ["debug" "info" "warning"], did you mean "warning"?`,
+		},
+		{
+			desc:    "#01",
+			lookup:  func() (string, error) { return e.LookupInCaseInsensitive("LOG_LEVEL", "DEBUG", "WARNING") },
+			wantMsg: `env: value is not in: LOG_LEVEL: value "wraning", in-case-insensitive ["DEBUG" "WARNING"], did you mean "WARNING"?`,
+		},
+		{
+			desc:    "#02",
+			lookup:  func() (string, error) { return e.LookupIn("LOG_FORMAT", "json", "text") },
+			wantMsg: `env: value is not in: LOG_FORMAT: value "xml", in ["json" "text"]`,
+		},
+		{
+			desc:    "#03",
+			lookup:  func() (string, error) { return e.Lookup("LOG_LEVLEE") },
+			wantMsg: `env: can not find key: LOG_LEVLEE (did you mean LOG_LEVLE?)`,
+		},
+		{
+			desc:    "#04",
+			lookup:  func() (string, error) { return e.Lookup("DB_HOST") },
+			wantMsg: `env: can not find key: DB_HOST`,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run("Suggest", func(t *testing.T) {
+			_, err := s.lookup()
+			if err == nil || err.Error() != s.wantMsg {
+				t.Errorf("%v: got '%v' want '%v'", s.desc, err, s.wantMsg)
+			}
+		})
+	}
+
+	_, err := e.Lookup("log_level")
+
+	var ee *env.Error
+	if !errors.As(err, &ee) || ee.Suggestion != "LOG_LEVEL" {
+		t.Errorf("got '%v' want suggestion '%v'", err, "LOG_LEVEL")
+	}
+}
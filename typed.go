@@ -0,0 +1,372 @@
+package env
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// Value is the set of types an environment variable can be parsed into.
+// time.Duration values are parsed with time.ParseDuration.
+type Value interface {
+	~string | ~bool |
+		~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// parse parses 's' into a value of type T.
+func parse[T Value](s string) (T, error) {
+	var value T
+	err := parseInto(s, reflect.ValueOf(&value).Elem())
+
+	return value, err
+}
+
+// parseInto parses 's' according to the type of 'v' and stores the result in 'v'.
+func parseInto(s string, v reflect.Value) error {
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported type %v", v.Type())
+	}
+
+	return nil
+}
+
+// Typed reads variables parsed as T from an Env.
+// It exists because methods can not have type parameters.
+type Typed[T Value] struct {
+	e *Env
+}
+
+// As returns the typed getters of 'e' for T.
+func As[T Value](e *Env) Typed[T] {
+	return Typed[T]{e: e}
+}
+
+// Get is like GetAs but reads from the source of the Env.
+func (t Typed[T]) Get(key string, defaultValue T) T {
+	return t.get(key, defaultValue)
+}
+
+// GetIn is like GetAsIn but reads from the source of the Env.
+func (t Typed[T]) GetIn(key string, defaultValue T, in ...T) T {
+	return t.get(key, defaultValue, func(key string, value T) error {
+		return checkAsIn(key, value, in)
+	})
+}
+
+// GetExcept is like GetAsExcept but reads from the source of the Env.
+func (t Typed[T]) GetExcept(key string, defaultValue T, except ...T) T {
+	return t.get(key, defaultValue, func(key string, value T) error {
+		return checkAsExcept(key, value, except)
+	})
+}
+
+// Lookup is like LookupAs but reads from the source of the Env.
+func (t Typed[T]) Lookup(key string) (T, error) {
+	return t.lookup(key)
+}
+
+// LookupIn is like LookupAsIn but reads from the source of the Env.
+func (t Typed[T]) LookupIn(key string, in ...T) (T, error) {
+	return t.lookup(key, func(key string, value T) error {
+		return checkAsIn(key, value, in)
+	})
+}
+
+// LookupExcept is like LookupAsExcept but reads from the source of the Env.
+func (t Typed[T]) LookupExcept(key string, except ...T) (T, error) {
+	return t.lookup(key, func(key string, value T) error {
+		return checkAsExcept(key, value, except)
+	})
+}
+
+// get reads 'key' as lookup does and returns 'defaultValue' if it is not set or rejected.
+func (t Typed[T]) get(key string, defaultValue T, checks ...func(key string, value T) error) T {
+	value, r := t.eval(t.e.Var(key).Default(fmt.Sprint(defaultValue)), nil, checks)
+	if r.Defaulted || r.Err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
+// lookup reads 'key', parses its value as T and applies 'checks' to the result.
+func (t Typed[T]) lookup(key string, checks ...func(key string, value T) error) (T, error) {
+	value, r := t.eval(t.e.Var(key), nil, checks)
+	if r.Err != nil {
+		var zero T
+		return zero, r.Err
+	}
+
+	return value, nil
+}
+
+// eval evaluates 'v', parsing its value as T and applying 'checks', described by 'desc', to the result.
+func (t Typed[T]) eval(v *Variable, desc []string, checks []func(key string, value T) error) (T, Result) {
+	var value T
+	r := v.satisfy(func(key, s string) error {
+		parsed, err := parse[T](s)
+		if err != nil {
+			return &Error{Key: key, Value: s, Constraint: fmt.Sprintf("type %T", parsed), Err: ErrInvalid}
+		}
+
+		for _, check := range checks {
+			if err := check(key, parsed); err != nil {
+				return err
+			}
+		}
+
+		value = parsed
+		return nil
+	}, desc...).Result()
+
+	return value, r
+}
+
+// MustGet is like MustGetAs but reads from the source of the Env.
+func (t Typed[T]) MustGet(key string) T {
+	return must(t.Lookup(key))
+}
+
+// MustGetIn is like MustGetAsIn but reads from the source of the Env.
+func (t Typed[T]) MustGetIn(key string, in ...T) T {
+	return must(t.LookupIn(key, in...))
+}
+
+// MustGetExcept is like MustGetAsExcept but reads from the source of the Env.
+func (t Typed[T]) MustGetExcept(key string, except ...T) T {
+	return must(t.LookupExcept(key, except...))
+}
+
+// GetInt is like the package-level GetInt but reads from the source of 'e'.
+func (e *Env) GetInt(key string, defaultValue int) int {
+	return As[int](e).Get(key, defaultValue)
+}
+
+// GetUint is like the package-level GetUint but reads from the source of 'e'.
+func (e *Env) GetUint(key string, defaultValue uint) uint {
+	return As[uint](e).Get(key, defaultValue)
+}
+
+// GetBool is like the package-level GetBool but reads from the source of 'e'.
+func (e *Env) GetBool(key string, defaultValue bool) bool {
+	return As[bool](e).Get(key, defaultValue)
+}
+
+// GetFloat64 is like the package-level GetFloat64 but reads from the source of 'e'.
+func (e *Env) GetFloat64(key string, defaultValue float64) float64 {
+	return As[float64](e).Get(key, defaultValue)
+}
+
+// GetDuration is like the package-level GetDuration but reads from the source of 'e'.
+func (e *Env) GetDuration(key string, defaultValue time.Duration) time.Duration {
+	return As[time.Duration](e).Get(key, defaultValue)
+}
+
+// MustGetInt is like the package-level MustGetInt but reads from the source of 'e'.
+func (e *Env) MustGetInt(key string) int {
+	return As[int](e).MustGet(key)
+}
+
+// MustGetUint is like the package-level MustGetUint but reads from the source of 'e'.
+func (e *Env) MustGetUint(key string) uint {
+	return As[uint](e).MustGet(key)
+}
+
+// MustGetBool is like the package-level MustGetBool but reads from the source of 'e'.
+func (e *Env) MustGetBool(key string) bool {
+	return As[bool](e).MustGet(key)
+}
+
+// MustGetFloat64 is like the package-level MustGetFloat64 but reads from the source of 'e'.
+func (e *Env) MustGetFloat64(key string) float64 {
+	return As[float64](e).MustGet(key)
+}
+
+// MustGetDuration is like the package-level MustGetDuration but reads from the source of 'e'.
+func (e *Env) MustGetDuration(key string) time.Duration {
+	return As[time.Duration](e).MustGet(key)
+}
+
+// GetAs returns the environment variable set in 'key' parsed as T.
+// If value is not set for 'key' or can not be parsed as T, it returns 'defaultValue'.
+func GetAs[T Value](key string, defaultValue T) T {
+	return As[T](std).Get(key, defaultValue)
+}
+
+// GetAsIn returns the environment variable set in 'key' parsed as T.
+// If value is not set for 'key', can not be parsed as T or different from 'in', it returns 'defaultValue'.
+func GetAsIn[T Value](key string, defaultValue T, in ...T) T {
+	return As[T](std).GetIn(key, defaultValue, in...)
+}
+
+// GetAsExcept returns the environment variable set in 'key' parsed as T.
+// If value is not set for 'key', can not be parsed as T or equal to 'except', it returns 'defaultValue'.
+func GetAsExcept[T Value](key string, defaultValue T, except ...T) T {
+	return As[T](std).GetExcept(key, defaultValue, except...)
+}
+
+// LookupAs returns the environment variable set in 'key' parsed as T.
+// If value is not set for 'key' or can not be parsed as T, it returns an *Error.
+func LookupAs[T Value](key string) (T, error) {
+	return As[T](std).Lookup(key)
+}
+
+// LookupAsIn returns the environment variable set in 'key' parsed as T.
+// If value is not set for 'key', can not be parsed as T or different from 'in', it returns an *Error.
+func LookupAsIn[T Value](key string, in ...T) (T, error) {
+	return As[T](std).LookupIn(key, in...)
+}
+
+// LookupAsExcept returns the environment variable set in 'key' parsed as T.
+// If value is not set for 'key', can not be parsed as T or equal to 'except', it returns an *Error.
+func LookupAsExcept[T Value](key string, except ...T) (T, error) {
+	return As[T](std).LookupExcept(key, except...)
+}
+
+// MustGetAs returns the environment variable set in 'key' parsed as T.
+// If value is not set for 'key' or can not be parsed as T, it raises a panic.
+func MustGetAs[T Value](key string) T {
+	return As[T](std).MustGet(key)
+}
+
+// MustGetAsIn returns the environment variable set in 'key' parsed as T.
+// If value is not set for 'key', can not be parsed as T or different from 'in', it raises a panic.
+func MustGetAsIn[T Value](key string, in ...T) T {
+	return As[T](std).MustGetIn(key, in...)
+}
+
+// MustGetAsExcept returns the environment variable set in 'key' parsed as T.
+// If value is not set for 'key', can not be parsed as T or equal to 'except', it raises a panic.
+func MustGetAsExcept[T Value](key string, except ...T) T {
+	return As[T](std).MustGetExcept(key, except...)
+}
+
+// GetInt returns the environment variable set in 'key' parsed as an int.
+// If value is not set for 'key' or is not an int, it returns 'defaultValue'.
+func GetInt(key string, defaultValue int) int {
+	return std.GetInt(key, defaultValue)
+}
+
+// GetUint returns the environment variable set in 'key' parsed as a uint.
+// If value is not set for 'key' or is not a uint, it returns 'defaultValue'.
+func GetUint(key string, defaultValue uint) uint {
+	return std.GetUint(key, defaultValue)
+}
+
+// GetBool returns the environment variable set in 'key' parsed as a bool.
+// If value is not set for 'key' or is not a bool, it returns 'defaultValue'.
+// Accepted values are those of strconv.ParseBool.
+func GetBool(key string, defaultValue bool) bool {
+	return std.GetBool(key, defaultValue)
+}
+
+// GetFloat64 returns the environment variable set in 'key' parsed as a float64.
+// If value is not set for 'key' or is not a float64, it returns 'defaultValue'.
+func GetFloat64(key string, defaultValue float64) float64 {
+	return std.GetFloat64(key, defaultValue)
+}
+
+// GetDuration returns the environment variable set in 'key' parsed as a time.Duration.
+// If value is not set for 'key' or is not a duration, it returns 'defaultValue'.
+// Accepted values are those of time.ParseDuration.
+func GetDuration(key string, defaultValue time.Duration) time.Duration {
+	return std.GetDuration(key, defaultValue)
+}
+
+// MustGetInt returns the environment variable set in 'key' parsed as an int.
+// If value is not set for 'key' or is not an int, it raises a panic.
+func MustGetInt(key string) int {
+	return std.MustGetInt(key)
+}
+
+// MustGetUint returns the environment variable set in 'key' parsed as a uint.
+// If value is not set for 'key' or is not a uint, it raises a panic.
+func MustGetUint(key string) uint {
+	return std.MustGetUint(key)
+}
+
+// MustGetBool returns the environment variable set in 'key' parsed as a bool.
+// If value is not set for 'key' or is not a bool, it raises a panic.
+func MustGetBool(key string) bool {
+	return std.MustGetBool(key)
+}
+
+// MustGetFloat64 returns the environment variable set in 'key' parsed as a float64.
+// If value is not set for 'key' or is not a float64, it raises a panic.
+func MustGetFloat64(key string) float64 {
+	return std.MustGetFloat64(key)
+}
+
+// MustGetDuration returns the environment variable set in 'key' parsed as a time.Duration.
+// If value is not set for 'key' or is not a duration, it raises a panic.
+func MustGetDuration(key string) time.Duration {
+	return std.MustGetDuration(key)
+}
+
+// checkAsIn returns an error unless 'value' is equal to one of 'in'.
+func checkAsIn[T Value](key string, value T, in []T) error {
+	for _, v := range in {
+		if value == v {
+			return nil
+		}
+	}
+
+	return &Error{Key: key, Value: fmt.Sprint(value), Constraint: fmt.Sprintf("in %v", in), Err: ErrNotAllowed}
+}
+
+// checkAsExcept returns an error if 'value' is equal to one of 'except'.
+func checkAsExcept[T Value](key string, value T, except []T) error {
+	for _, v := range except {
+		if value == v {
+			return &Error{Key: key, Value: fmt.Sprint(value), Constraint: fmt.Sprintf("except %v", except), Err: ErrExcluded}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,528 @@
+package env
+
+// Variable describes a variable, its default value and the constraints its value must satisfy.
+// It is built with Var and evaluated with String, Lookup or MustString.
+//
+// Constraints are evaluated in declaration order and the first one that fails
+// rejects the value. With a single constraint, a Variable behaves exactly like
+// the matching function, e.g. Var(k).Default(d).OneOf(in...).String() is GetIn(k, d, in...).
+type Variable struct {
+	e          *Env
+	key        string
+	def        string
+	hasDefault bool
+	fold       bool
+	checks     checkList
+
+	// description and secret document the variable in the registry of the Env.
+	description string
+	secret      bool
+
+	// sep, kvSep, keepSpace and unique configure List and Map.
+	sep, kvSep string
+	keepSpace  bool
+	unique     bool
+
+	emptyAsUnset bool
+
+	// canonical and aliases normalize the value to one of the declared spellings.
+	canonical bool
+	aliases   []alias
+}
+
+// alias maps alternative spellings to a canonical value.
+type alias struct {
+	canonical string
+	names     []string
+}
+
+type checkKind int
+
+const (
+	kindIn checkKind = iota
+	kindExcept
+	kindRegex
+	kindExceptRegex
+	kindMatcher
+	kindExceptMatcher
+	kindNonEmpty
+	kindFunc
+	kindGlob
+	kindExceptGlob
+)
+
+// check is a single constraint declared on a Variable.
+type check struct {
+	kind     checkKind
+	values   []string
+	matchers []Matcher
+	// fn is the constraint of kindFunc checks.
+	fn func(key, value string) error
+}
+
+// checkList holds the checks of a Variable, the first one inline so that
+// a Variable with a single constraint does not allocate.
+type checkList struct {
+	first check
+	rest  []check
+	n     int
+}
+
+func (l *checkList) add(c check) {
+	if l.n == 0 {
+		l.first = c
+	} else {
+		l.rest = append(l.rest, c)
+	}
+
+	l.n++
+}
+
+func (l *checkList) at(i int) *check {
+	if i == 0 {
+		return &l.first
+	}
+
+	return &l.rest[i-1]
+}
+
+// apply returns an error if 'value' does not satisfy the constraint.
+func (c check) apply(key, value string, fold bool) error {
+	switch c.kind {
+	case kindIn:
+		return checkIn(key, value, c.values, fold)
+	case kindExcept:
+		return checkExcept(key, value, c.values, fold)
+	case kindRegex:
+		return checkInRegex(key, value, c.values)
+	case kindExceptRegex:
+		return checkExceptRegex(key, value, c.values)
+	case kindMatcher:
+		return checkInMatcher(key, value, c.matchers)
+	case kindNonEmpty:
+		return checkNonEmpty(key, value)
+	case kindFunc:
+		return c.fn(key, value)
+	case kindGlob:
+		return checkInGlob(key, value, c.values, fold)
+	case kindExceptGlob:
+		return checkExceptGlob(key, value, c.values, fold)
+	default:
+		return checkExceptMatcher(key, value, c.matchers)
+	}
+}
+
+// Var returns a Variable reading 'key' from the source of 'e'.
+// The key of the Variable is qualified with the prefix of 'e'.
+func (e *Env) Var(key string) *Variable {
+	return &Variable{e: e, key: e.key(key)}
+}
+
+// Var returns a Variable reading 'key' from the environment.
+func Var(key string) *Variable {
+	return std.Var(key)
+}
+
+// Default sets the value used when the variable is not set or is rejected by a constraint.
+func (v *Variable) Default(value string) *Variable {
+	v.def, v.hasDefault = value, true
+	return v
+}
+
+// OneOf requires the value to be one of 'values', as in GetIn.
+func (v *Variable) OneOf(values ...string) *Variable {
+	v.checks.add(check{kind: kindIn, values: values})
+	return v
+}
+
+// NotOneOf requires the value not to be one of 'values', as in GetExcept.
+func (v *Variable) NotOneOf(values ...string) *Variable {
+	v.checks.add(check{kind: kindExcept, values: values})
+	return v
+}
+
+// Match requires the value to match one of the regular expressions 'regex', as in GetInRegex.
+func (v *Variable) Match(regex ...string) *Variable {
+	v.checks.add(check{kind: kindRegex, values: regex})
+	return v
+}
+
+// NotMatch requires the value not to match the regular expressions 'regex', as in GetExceptRegex.
+func (v *Variable) NotMatch(regex ...string) *Variable {
+	v.checks.add(check{kind: kindExceptRegex, values: regex})
+	return v
+}
+
+// Glob requires the value to match one of the glob patterns 'glob', as in GetInGlob.
+func (v *Variable) Glob(glob ...string) *Variable {
+	v.checks.add(check{kind: kindGlob, values: glob})
+	return v
+}
+
+// NotGlob requires the value not to match the glob patterns 'glob', as in GetExceptGlob.
+func (v *Variable) NotGlob(glob ...string) *Variable {
+	v.checks.add(check{kind: kindExceptGlob, values: glob})
+	return v
+}
+
+// MatchWith requires the value to match one of 'm', as in GetInMatcher.
+func (v *Variable) MatchWith(m ...Matcher) *Variable {
+	v.checks.add(check{kind: kindMatcher, matchers: m})
+	return v
+}
+
+// NotMatchWith requires the value not to match any of 'm', as in GetExceptMatcher.
+func (v *Variable) NotMatchWith(m ...Matcher) *Variable {
+	v.checks.add(check{kind: kindExceptMatcher, matchers: m})
+	return v
+}
+
+// satisfy requires 'fn' to accept the value, e.g. to parse it into another type.
+// 'desc' describes the constraints 'fn' applies, for the registry.
+func (v *Variable) satisfy(fn func(key, value string) error, desc ...string) *Variable {
+	v.checks.add(check{kind: kindFunc, fn: fn, values: desc})
+	return v
+}
+
+// NonEmpty requires the value not to be empty or made only of spaces, as in MustGetNonEmpty.
+func (v *Variable) NonEmpty() *Variable {
+	v.checks.add(check{kind: kindNonEmpty})
+	return v
+}
+
+// EmptyAsUnset treats an empty value or a value made only of spaces as not set, as WithEmptyAsUnset does for an Env.
+func (v *Variable) EmptyAsUnset() *Variable {
+	v.emptyAsUnset = true
+	return v
+}
+
+// CaseInsensitive makes every OneOf, NotOneOf, Glob and NotGlob constraint of the variable case insensitive.
+func (v *Variable) CaseInsensitive() *Variable {
+	v.fold = true
+	return v
+}
+
+// Canonical returns the entry of the OneOf constraints matching the value instead of the
+// value itself, so that a case insensitive variable set to "PROD" returns "prod".
+func (v *Variable) Canonical() *Variable {
+	v.canonical = true
+	return v
+}
+
+// Alias replaces the values equal to one of 'aliases' with 'canonical' before the
+// constraints are applied, e.g. Alias("prod", "production", "prd").
+// Aliases are case insensitive if the variable is.
+func (v *Variable) Alias(canonical string, aliases ...string) *Variable {
+	v.aliases = append(v.aliases, alias{canonical: canonical, names: aliases})
+	return v
+}
+
+// Describe sets the description of the variable recorded in the registry of the Env.
+func (v *Variable) Describe(description string) *Variable {
+	v.description = description
+	return v
+}
+
+// Secret marks the value of the variable as sensitive, so that it is never displayed,
+// including in the errors and the later reads of its key through the Env and its copies.
+func (v *Variable) Secret() *Variable {
+	v.secret = true
+	v.e.secrets.Store(v.key, struct{}{})
+	return v
+}
+
+// Declare records the variable in the registry of the Env without reading it.
+// Variables are also recorded whenever they are read.
+func (v *Variable) Declare() *Variable {
+	if v.e.registry != nil {
+		v.e.registry.Declare(v.decl())
+	}
+
+	return v
+}
+
+// decl returns the declaration of the variable.
+func (v *Variable) decl() Decl {
+	d := Decl{
+		Key:             v.key,
+		Description:     v.description,
+		Default:         v.def,
+		HasDefault:      v.hasDefault,
+		Required:        !v.hasDefault,
+		Secret:          v.secret,
+		CaseInsensitive: v.fold,
+	}
+
+	for i := 0; i < v.checks.n; i++ {
+		c := v.checks.at(i)
+		switch c.kind {
+		case kindIn:
+			d.In = append(d.In, c.values...)
+		case kindExcept:
+			d.Except = append(d.Except, c.values...)
+		case kindRegex:
+			d.Match = append(d.Match, c.values...)
+		case kindExceptRegex:
+			d.NotMatch = append(d.NotMatch, c.values...)
+		case kindMatcher:
+			d.Match = append(d.Match, matcherStrings(c.matchers)...)
+		case kindExceptMatcher:
+			d.NotMatch = append(d.NotMatch, matcherStrings(c.matchers)...)
+		case kindNonEmpty:
+			d.NonEmpty = true
+		case kindFunc:
+			d.Range = append(d.Range, c.values...)
+		case kindGlob:
+			d.Glob = append(d.Glob, c.values...)
+		case kindExceptGlob:
+			d.NotGlob = append(d.NotGlob, c.values...)
+		}
+	}
+
+	return d
+}
+
+// declaredIn reports whether 'd' already records every field the variable declares,
+// so that reading it again does not need to declare it.
+func (v *Variable) declaredIn(d Decl) bool {
+	switch {
+	case v.description != "" && v.description != d.Description,
+		v.secret && !d.Secret,
+		v.hasDefault && (!d.HasDefault || v.def != d.Default):
+		return false
+	}
+
+	var in, except, match, notMatch, rng, glob, notGlob int
+	folds := false
+	for i := 0; i < v.checks.n; i++ {
+		c := v.checks.at(i)
+		ok := true
+		switch c.kind {
+		case kindIn:
+			ok, folds = hasPrefixAt(d.In, &in, c.values), true
+		case kindExcept:
+			ok, folds = hasPrefixAt(d.Except, &except, c.values), true
+		case kindRegex:
+			ok = hasPrefixAt(d.Match, &match, c.values)
+		case kindExceptRegex:
+			ok = hasPrefixAt(d.NotMatch, &notMatch, c.values)
+		case kindMatcher:
+			ok = hasMatchersAt(d.Match, &match, c.matchers)
+		case kindExceptMatcher:
+			ok = hasMatchersAt(d.NotMatch, &notMatch, c.matchers)
+		case kindNonEmpty:
+			ok = d.NonEmpty
+		case kindFunc:
+			ok = hasPrefixAt(d.Range, &rng, c.values)
+		case kindGlob:
+			ok, folds = hasPrefixAt(d.Glob, &glob, c.values), true
+		case kindExceptGlob:
+			ok, folds = hasPrefixAt(d.NotGlob, &notGlob, c.values), true
+		}
+
+		if !ok {
+			return false
+		}
+	}
+
+	// A declaration replaces the non-empty lists, so every list the variable sets must match entirely.
+	return (in == 0 || in == len(d.In)) && (except == 0 || except == len(d.Except)) &&
+		(match == 0 || match == len(d.Match)) && (notMatch == 0 || notMatch == len(d.NotMatch)) &&
+		(rng == 0 || rng == len(d.Range)) && (glob == 0 || glob == len(d.Glob)) &&
+		(notGlob == 0 || notGlob == len(d.NotGlob)) && (!folds || v.fold == d.CaseInsensitive)
+}
+
+// hasPrefixAt reports whether 'values' are recorded in 'list' at index '*i', and moves '*i' past them.
+func hasPrefixAt(list []string, i *int, values []string) bool {
+	if len(list)-*i < len(values) {
+		return false
+	}
+
+	for _, s := range values {
+		if list[*i] != s {
+			return false
+		}
+		*i++
+	}
+
+	return true
+}
+
+// hasMatchersAt is like hasPrefixAt for the descriptions of 'matchers'.
+func hasMatchersAt(list []string, i *int, matchers []Matcher) bool {
+	if len(list)-*i < len(matchers) {
+		return false
+	}
+
+	for _, m := range matchers {
+		if list[*i] != matcherString(m) {
+			return false
+		}
+		*i++
+	}
+
+	return true
+}
+
+// read records the variable in the registry of the Env and returns its value and origin,
+// empty if it is not set. A variable is declared again only if it changes its recorded
+// declaration, so that repeated reads stay cheap.
+func (v *Variable) read() (string, string, error) {
+	if r := v.e.registry; r != nil {
+		if d, ok := r.Lookup(v.key); !ok || !v.declaredIn(d) {
+			r.Declare(v.decl())
+		}
+	}
+
+	return v.e.lookup(v.key, v.emptyAsUnset || v.e.emptyAsUnset)
+}
+
+// Result is the outcome of the evaluation of a Variable.
+type Result struct {
+	// Value is the value of the variable, or its default value if Defaulted is set.
+	Value string
+	// Set reports whether the variable is set in the source.
+	Set bool
+	// Origin is where Value comes from: OriginSource, OriginFile or OriginDefault,
+	// empty if the variable is not set and has no default.
+	Origin string
+	// Defaulted reports whether Value is the default value, because the variable
+	// is not set or its value is rejected by a constraint.
+	Defaulted bool
+	// Err is the reason the value of the variable was not used,
+	// nil if it is not set and has a default value.
+	Err error
+}
+
+// Result evaluates the variable and reports where its value comes from.
+// Constraints are always applied to the value set in the source, even if it is equal to the default value.
+func (v *Variable) Result() Result {
+	return v.evaluate(func(value string) (string, error) {
+		value = v.unalias(value)
+		if err := v.validate(value); err != nil {
+			return "", err
+		}
+
+		return v.normalize(value), nil
+	})
+}
+
+// evaluate reads the variable, passes its value to 'accept' if it is set and
+// reports the outcome to the observers of the Env.
+func (v *Variable) evaluate(accept func(value string) (string, error)) Result {
+	value, origin, err := v.read()
+	r := Result{Set: origin != "", Origin: origin}
+
+	switch {
+	case err != nil:
+		r.Err = err
+	case !r.Set && !v.hasDefault:
+		r.Err = v.e.notSet(v.key)
+	case r.Set:
+		value, r.Err = accept(value)
+	}
+
+	if r.Set && r.Err == nil {
+		r.Value = value
+	} else {
+		r.Value, r.Defaulted, r.Origin = v.def, v.hasDefault, ""
+		if v.hasDefault {
+			r.Origin = OriginDefault
+		}
+	}
+
+	if r.Err != nil && v.isSecret() {
+		r.Err = redact(r.Err)
+	}
+
+	v.e.notify(v, r)
+	return r
+}
+
+// isSecret reports whether the variable, or its key in the Env or its registry, is marked secret.
+func (v *Variable) isSecret() bool {
+	if v.secret {
+		return true
+	}
+
+	if _, ok := v.e.secrets.Load(v.key); ok {
+		return true
+	}
+
+	if r := v.e.registry; r != nil {
+		d, ok := r.Lookup(v.key)
+		return ok && d.Secret
+	}
+
+	return false
+}
+
+// String returns the value of the variable.
+// If the variable is not set or its value is rejected by a constraint, it returns the default value.
+func (v *Variable) String() string {
+	return v.Result().Value
+}
+
+// Lookup returns the value of the variable.
+// If the variable is not set and has no default, or its value is rejected by a constraint,
+// it returns an *Error.
+func (v *Variable) Lookup() (string, error) {
+	r := v.Result()
+	if r.Err != nil {
+		return "", r.Err
+	}
+
+	return r.Value, nil
+}
+
+// MustString is like Lookup but raises a panic with the error instead of returning it.
+func (v *Variable) MustString() string {
+	return must(v.Lookup())
+}
+
+// unalias returns the canonical value of 'value' if it is an alias.
+func (v *Variable) unalias(value string) string {
+	for _, a := range v.aliases {
+		for _, name := range a.names {
+			if equal(value, name, v.fold) {
+				return a.canonical
+			}
+		}
+	}
+
+	return value
+}
+
+// normalize returns the entry of the OneOf constraints matching 'value' if the variable is Canonical.
+func (v *Variable) normalize(value string) string {
+	if !v.canonical {
+		return value
+	}
+
+	for i := 0; i < v.checks.n; i++ {
+		c := v.checks.at(i)
+		if c.kind != kindIn {
+			continue
+		}
+
+		for _, in := range c.values {
+			if equal(value, in, v.fold) {
+				return in
+			}
+		}
+	}
+
+	return value
+}
+
+// validate applies the constraints of the variable to 'value' in declaration order.
+func (v *Variable) validate(value string) error {
+	for i := 0; i < v.checks.n; i++ {
+		c := v.checks.at(i)
+		if err := c.apply(v.key, value, v.fold); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
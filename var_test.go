@@ -0,0 +1,98 @@
+package env_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gomodrepo/env"
+)
+
+func TestVar(t *testing.T) {
+	e := env.New(env.Map{
+		"MODE":   "prod",
+		"UPPER":  "PROD",
+		"TEST":   "test-1",
+		"REGION": "eu-1",
+	})
+
+	scenarios := []struct {
+		desc      string
+		v         *env.Variable
+		wantValue string
+		wantErr   error
+	}{
+		{
+			desc:      "#00",
+			v:         e.Var(_testKey).Default("dev"),
+			wantValue: "dev",
+		},
+		{
+			desc:    "#01",
+			v:       e.Var(_testKey),
+			wantErr: env.ErrNotSet,
+		},
+		{
+			desc:      "#02",
+			v:         e.Var("MODE").Default("dev").OneOf("dev", "prod").NotMatch(`^test`),
+			wantValue: "prod",
+		},
+		{
+			desc:      "#03",
+			v:         e.Var("UPPER").Default("dev").OneOf("dev", "prod").NotMatch(`^test`).CaseInsensitive(),
+			wantValue: "PROD",
+		},
+		{
+			desc:      "#04",
+			v:         e.Var("UPPER").Default("dev").OneOf("dev", "prod"),
+			wantValue: "dev",
+			wantErr:   env.ErrNotAllowed,
+		},
+		{
+			desc:      "#05",
+			v:         e.Var("TEST").Default("dev").Match(`-[0-9]$`).NotMatch(`^test`),
+			wantValue: "dev",
+			wantErr:   env.ErrExcluded,
+		},
+		{
+			desc:      "#06",
+			v:         e.Var("TEST").Default("dev").NotOneOf("test-1").Match("("),
+			wantValue: "dev",
+			wantErr:   env.ErrExcluded,
+		},
+		{
+			desc:      "#07",
+			v:         e.Var("TEST").Default("dev").Match("(").NotOneOf("test-1"),
+			wantValue: "dev",
+			wantErr:   env.ErrBadPattern,
+		},
+		{
+			desc:      "#08",
+			v:         e.Var("REGION").MatchWith(env.MustCompile(`^eu-`)).NotMatchWith(env.MustCompile(`-0$`)),
+			wantValue: "eu-1",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run("Var", func(t *testing.T) {
+			if got := s.v.String(); got != s.wantValue {
+				t.Errorf("%v: got '%v' want '%v'", s.desc, got, s.wantValue)
+			}
+
+			_, err := s.v.Lookup()
+			if !errors.Is(err, s.wantErr) {
+				t.Errorf("%v: got error '%v' want '%v'", s.desc, err, s.wantErr)
+			}
+
+			func() {
+				defer func() {
+					p := recover()
+					if (p == nil) != (s.wantErr == nil) {
+						t.Errorf("%v: gotPanic '%v' wantPanic '%v'", s.desc, p, s.wantErr != nil)
+					}
+				}()
+
+				s.v.MustString()
+			}()
+		})
+	}
+}